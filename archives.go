@@ -0,0 +1,76 @@
+package gorthanc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// createArchiveRequest is the body of /tools/create-archive and /tools/create-media
+type createArchiveRequest struct {
+	Resources   []string `json:"Resources"`
+	Synchronous bool     `json:"Synchronous"`
+	*types.CreateArchiveOptions
+}
+
+// CreateArchive builds a single ZIP archive of several patients, studies, series or instances
+// This endpoint implements the POST /tools/create-archive request in synchronous mode
+// The caller is responsible for closing the response body.
+func (c *Client) CreateArchive(ctx context.Context, resources []string, opts *types.CreateArchiveOptions) (*http.Response, error) {
+	return c.createArchive(ctx, "tools/create-archive", resources, opts)
+}
+
+// CreateArchiveAsync submits the creation of a ZIP archive of several resources as an Orthanc job
+// This endpoint implements the POST /tools/create-archive request in asynchronous mode
+// Once the job succeeded, download the archive with JobHandle.DownloadArchive.
+func (c *Client) CreateArchiveAsync(ctx context.Context, resources []string, opts *types.CreateArchiveOptions) (*JobHandle, error) {
+	body := createArchiveRequest{Resources: resources, Synchronous: false, CreateArchiveOptions: opts}
+	return c.submitJob(ctx, "tools/create-archive", body)
+}
+
+// CreateMedia builds a single ZIP archive with a DICOMDIR of several resources
+// This endpoint implements the POST /tools/create-media request in synchronous mode
+// Set opts.Extended to generate an extended DICOMDIR.
+// The caller is responsible for closing the response body.
+func (c *Client) CreateMedia(ctx context.Context, resources []string, opts *types.CreateArchiveOptions) (*http.Response, error) {
+	return c.createArchive(ctx, "tools/create-media", resources, opts)
+}
+
+// CreateMediaAsync submits the creation of a ZIP archive with a DICOMDIR of several resources as an Orthanc job
+// This endpoint implements the POST /tools/create-media request in asynchronous mode
+// Once the job succeeded, download the archive with JobHandle.DownloadArchive.
+func (c *Client) CreateMediaAsync(ctx context.Context, resources []string, opts *types.CreateArchiveOptions) (*JobHandle, error) {
+	body := createArchiveRequest{Resources: resources, Synchronous: false, CreateArchiveOptions: opts}
+	return c.submitJob(ctx, "tools/create-media", body)
+}
+
+// createArchive posts a synchronous archive creation request and returns the streamed ZIP
+func (c *Client) createArchive(ctx context.Context, path string, resources []string, opts *types.CreateArchiveOptions) (*http.Response, error) {
+	body := createArchiveRequest{Resources: resources, Synchronous: true, CreateArchiveOptions: opts}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/zip")
+
+	return c.doRequestWithHeaders(ctx, http.MethodPost, path, bytes.NewReader(bodyBytes), header)
+}
+
+// DownloadArchive downloads the ZIP archive produced by an archive or media job
+// The caller is responsible for closing the response body.
+func (h *JobHandle) DownloadArchive(ctx context.Context) (*http.Response, error) {
+	return h.client.DownloadJobArchive(ctx, h.ID)
+}
+
+// SaveArchive streams the ZIP archive produced by an archive or media job to w
+func (h *JobHandle) SaveArchive(ctx context.Context, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+	return h.client.saveDownload(ctx, endpointPath("jobs", h.ID, "archive"), w, opts)
+}
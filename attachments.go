@@ -0,0 +1,124 @@
+package gorthanc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// GetAttachments lists the names of the files attached to a resource
+// This endpoint implements the GET /{level}/{id}/attachments request
+func (c *Client) GetAttachments(ctx context.Context, level types.ResourceLevel, resourceID string) ([]string, error) {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if err := c.get(ctx, path+"/attachments", &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// GetAttachmentInfo retrieves information about a file attached to a resource
+// This endpoint implements the GET /{level}/{id}/attachments/{name}/info request
+func (c *Client) GetAttachmentInfo(ctx context.Context, level types.ResourceLevel, resourceID string, name string) (*types.AttachmentInfo, error) {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, path+"/"+endpointPath("attachments", name, "info"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var info types.AttachmentInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	info.Revision = revisionFromResponse(resp)
+
+	return &info, nil
+}
+
+// DownloadAttachment downloads the uncompressed content of a file attached to a resource
+// This endpoint implements the GET /{level}/{id}/attachments/{name}/data request
+// The revision of the attachment is reported in the ETag header of the response.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadAttachment(ctx context.Context, level types.ResourceLevel, resourceID string, name string) (*http.Response, error) {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getWithAcceptRawResponse(ctx, path+"/"+endpointPath("attachments", name, "data"), "*/*")
+}
+
+// GetAttachment retrieves the uncompressed content of a file attached to a resource along with its revision
+func (c *Client) GetAttachment(ctx context.Context, level types.ResourceLevel, resourceID string, name string) (*types.Attachment, error) {
+	resp, err := c.DownloadAttachment(ctx, level, resourceID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &types.Attachment{
+		Name:     name,
+		Data:     data,
+		Revision: revisionFromResponse(resp),
+	}, nil
+}
+
+// SetAttachment creates or replaces a file attached to a resource
+// This endpoint implements the PUT /{level}/{id}/attachments/{name} request
+// The name must be declared in the UserContentType option of the Orthanc configuration.
+// When revision is not empty it is sent as If-Match, so the update fails with a 409 Conflict
+// if the attachment was modified in the meantime.
+func (c *Client) SetAttachment(ctx context.Context, level types.ResourceLevel, resourceID string, name string, data io.Reader, revision string) error {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return err
+	}
+
+	header := revisionHeader(revision)
+	header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodPut, path+"/"+endpointPath("attachments", name), data, header)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// DeleteAttachment deletes a file attached to a resource
+// This endpoint implements the DELETE /{level}/{id}/attachments/{name} request
+// See SetAttachment for the handling of revision.
+func (c *Client) DeleteAttachment(ctx context.Context, level types.ResourceLevel, resourceID string, name string, revision string) error {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodDelete, path+"/"+endpointPath("attachments", name), nil, revisionHeader(revision))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
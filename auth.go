@@ -0,0 +1,145 @@
+package gorthanc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is considered expired,
+// so that it does not expire while a request is in flight
+const tokenExpiryDelta = 10 * time.Second
+
+// Token is an access token sent as a Bearer authorization header
+type Token struct {
+	// The access token itself
+	AccessToken string
+
+	// Expiry time of the token, the zero value means the token never expires
+	Expiry time.Time
+}
+
+// expired reports whether the token must be refreshed before being used
+func (t *Token) expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return time.Now().Add(tokenExpiryDelta).After(t.Expiry)
+}
+
+// TokenSource provides access tokens, e.g. from an OAuth2 provider
+// Token is called whenever the cached token is missing, expired, or rejected with a 401.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function to the TokenSource interface
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token calls f(ctx)
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// cachingTokenSource caches the token of a TokenSource until it expires
+type cachingTokenSource struct {
+	source TokenSource
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// Token returns the cached token, fetching a new one if needed
+func (s *cachingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && !s.token.expired() {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	if token == nil || token.AccessToken == "" {
+		return nil, fmt.Errorf("failed to get access token: token source returned an empty token")
+	}
+
+	s.token = token
+	return token, nil
+}
+
+// invalidate drops the cached token if it is still the given one
+func (s *cachingTokenSource) invalidate(token *Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = nil
+	}
+}
+
+// authorize sets the static headers and credentials on a request
+// It returns the token that was used, if any, so it can be invalidated on a 401.
+func (c *Client) authorize(req *http.Request) (*Token, error) {
+	// Static headers never override the ones set for this specific request
+	for name, value := range c.headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	if c.tokenSource == nil {
+		return nil, nil
+	}
+
+	token, err := c.tokenSource.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	return token, nil
+}
+
+// sendAuthorized authorizes and sends a request
+// With a token source, a 401 response invalidates the token and the request is
+// sent once more with a fresh token, provided its body can be replayed.
+func (c *Client) sendAuthorized(req *http.Request) (*http.Response, error) {
+	token, err := c.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || token == nil || !isReplayable(req) {
+		return resp, err
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	c.tokenSource.invalidate(token)
+
+	next, err := cloneForRetry(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.authorize(next); err != nil {
+		return nil, err
+	}
+
+	return c.send(next)
+}
@@ -0,0 +1,76 @@
+package gorthanc
+
+import (
+	"context"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// GetChanges retrieves a page of the changes log
+// This endpoint implements the GET /changes request
+func (c *Client) GetChanges(ctx context.Context, params *types.ChangesQueryParams) (*types.ChangesResponse, error) {
+	path := c.buildChangesPath("changes", params)
+
+	var changes types.ChangesResponse
+	if err := c.get(ctx, path, &changes); err != nil {
+		return nil, err
+	}
+
+	return &changes, nil
+}
+
+// GetLastChange retrieves the last entry of the changes log
+// This endpoint implements the GET /changes?last request
+// Its Last field is the sequence number to start from to only see future changes.
+func (c *Client) GetLastChange(ctx context.Context) (*types.ChangesResponse, error) {
+	var changes types.ChangesResponse
+	if err := c.get(ctx, "changes?last", &changes); err != nil {
+		return nil, err
+	}
+
+	return &changes, nil
+}
+
+// ClearChanges clears the changes log
+// This endpoint implements the DELETE /changes request
+func (c *Client) ClearChanges(ctx context.Context) error {
+	return c.delete(ctx, "changes", nil)
+}
+
+// GetExports retrieves a page of the exports log
+// This endpoint implements the GET /exports request
+func (c *Client) GetExports(ctx context.Context, params *types.ChangesQueryParams) (*types.ExportsResponse, error) {
+	path := c.buildChangesPath("exports", params)
+
+	var exports types.ExportsResponse
+	if err := c.get(ctx, path, &exports); err != nil {
+		return nil, err
+	}
+
+	return &exports, nil
+}
+
+// ClearExports clears the exports log
+// This endpoint implements the DELETE /exports request
+func (c *Client) ClearExports(ctx context.Context) error {
+	return c.delete(ctx, "exports", nil)
+}
+
+// buildChangesPath constructs the path with query parameters
+func (c *Client) buildChangesPath(basePath string, params *types.ChangesQueryParams) string {
+	if params == nil {
+		return basePath
+	}
+
+	var query queryBuilder
+
+	if params.Since > 0 {
+		query.addInt64("since", params.Since)
+	}
+
+	if params.Limit > 0 {
+		query.addInt("limit", params.Limit)
+	}
+
+	return basePath + query.encode()
+}
@@ -0,0 +1,244 @@
+package gorthanc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+const (
+	defaultChangesPollInterval = time.Second
+	defaultChangesBatchSize    = 100
+)
+
+// CheckpointStore persists the sequence number of the last processed change
+type CheckpointStore interface {
+	// Load returns the last saved sequence number, or 0 if none was saved yet
+	Load(ctx context.Context) (int64, error)
+
+	// Save records the sequence number of the last processed change
+	Save(ctx context.Context, seq int64) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory, it is lost when the process exits
+type MemoryCheckpointStore struct {
+	mu  sync.Mutex
+	seq int64
+}
+
+// Load returns the last saved sequence number
+func (s *MemoryCheckpointStore) Load(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seq, nil
+}
+
+// Save records the sequence number of the last processed change
+func (s *MemoryCheckpointStore) Save(ctx context.Context, seq int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq = seq
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a file
+// The file is replaced atomically, so a crash never leaves a partially written checkpoint.
+type FileCheckpointStore struct {
+	// Path of the checkpoint file
+	Path string
+}
+
+// Load returns the sequence number saved in the file, or 0 if the file does not exist
+func (s *FileCheckpointStore) Load(ctx context.Context) (int64, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	seq, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+
+	return seq, nil
+}
+
+// Save writes the sequence number to the file
+func (s *FileCheckpointStore) Save(ctx context.Context, seq int64) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(strconv.FormatInt(seq, 10)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// ChangesWatcherOptions configures a ChangesWatcher
+type ChangesWatcherOptions struct {
+	// Delay between two polls once the end of the changes log is reached (default: 1s)
+	PollInterval time.Duration
+
+	// Maximum number of changes fetched per request (default: 100)
+	BatchSize int
+
+	// Only deliver changes of these types, all changes are delivered if empty
+	ChangeTypes []types.ChangeType
+
+	// Store persisting the last processed sequence number (default: in memory)
+	Checkpoint CheckpointStore
+
+	// Without a saved checkpoint, skip the existing changes and only deliver new ones
+	StartFromLast bool
+}
+
+// ChangesWatcher follows the Orthanc changes log
+type ChangesWatcher struct {
+	client *Client
+	opts   ChangesWatcherOptions
+}
+
+// NewChangesWatcher creates a watcher of the changes log
+func (c *Client) NewChangesWatcher(opts *ChangesWatcherOptions) *ChangesWatcher {
+	w := &ChangesWatcher{client: c}
+	if opts != nil {
+		w.opts = *opts
+	}
+
+	if w.opts.PollInterval <= 0 {
+		w.opts.PollInterval = defaultChangesPollInterval
+	}
+	if w.opts.BatchSize <= 0 {
+		w.opts.BatchSize = defaultChangesBatchSize
+	}
+	if w.opts.Checkpoint == nil {
+		w.opts.Checkpoint = &MemoryCheckpointStore{}
+	}
+
+	return w
+}
+
+// Run polls the changes log and calls handler for every matching change, in order
+// The checkpoint is saved after each page of changes, and before returning. If handler
+// returns an error, Run stops and the failed change is delivered again on the next run.
+// Run returns when ctx is done, with the context error.
+func (w *ChangesWatcher) Run(ctx context.Context, handler func(ctx context.Context, change types.Change) error) error {
+	since, err := w.opts.Checkpoint.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if since == 0 && w.opts.StartFromLast {
+		last, err := w.client.GetLastChange(ctx)
+		if err != nil {
+			return err
+		}
+		since = last.Last
+	}
+
+	saved := since
+	save := func() error {
+		if since == saved {
+			return nil
+		}
+		// Use a fresh context so the checkpoint is saved even when ctx was cancelled
+		if err := w.opts.Checkpoint.Save(context.WithoutCancel(ctx), since); err != nil {
+			return err
+		}
+		saved = since
+		return nil
+	}
+
+	for {
+		changes, err := w.client.GetChanges(ctx, &types.ChangesQueryParams{Since: since, Limit: w.opts.BatchSize})
+		if err != nil {
+			return errors.Join(err, save())
+		}
+
+		for _, change := range changes.Changes {
+			if w.matches(change) {
+				if err := handler(ctx, change); err != nil {
+					return errors.Join(err, save())
+				}
+			}
+			since = change.Seq
+		}
+
+		if changes.Last > since {
+			since = changes.Last
+		}
+		if err := save(); err != nil {
+			return err
+		}
+
+		if !changes.Done {
+			continue
+		}
+
+		timer := time.NewTimer(w.opts.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Watch polls the changes log in the background and delivers matching changes over a channel
+// Both channels are closed once watching stops. The error channel receives the error that stopped
+// the watcher, unless it stopped because ctx was done. A change counts as processed once it has
+// been received from the channel.
+func (w *ChangesWatcher) Watch(ctx context.Context) (<-chan types.Change, <-chan error) {
+	changes := make(chan types.Change)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(changes)
+
+		err := w.Run(ctx, func(ctx context.Context, change types.Change) error {
+			select {
+			case changes <- change:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return changes, errs
+}
+
+// matches reports whether a change passes the change type filter
+func (w *ChangesWatcher) matches(change types.Change) bool {
+	return len(w.opts.ChangeTypes) == 0 || slices.Contains(w.opts.ChangeTypes, change.ChangeType)
+}
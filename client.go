@@ -1,6 +1,7 @@
 package gorthanc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,14 @@ type Client struct {
 	httpClient *http.Client
 	username   string
 	password   string
+
+	bearerToken string
+	tokenSource *cachingTokenSource
+	headers     map[string]string
+
+	retryPolicy *RetryPolicy
+	middlewares []Middleware
+	roundTrip   RoundTripFunc
 }
 
 func NewClient(baseURL string, opts ...ClientOption) (*Client, error) {
@@ -40,34 +49,51 @@ func NewClient(baseURL string, opts ...ClientOption) (*Client, error) {
 		opt(client)
 	}
 
+	client.roundTrip = client.buildRoundTrip()
+
 	return client, nil
 }
 
-func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
-	return c.doRequestWithAccept(method, path, body, "application/json")
+// doRequest performs a request expecting a JSON response.
+// The context governs the whole exchange, including reading the response body:
+// for the methods returning a raw *http.Response, cancelling it aborts the transfer mid-stream.
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	return c.doRequestWithAccept(ctx, method, path, body, "application/json")
 }
 
-func (c *Client) doRequestWithAccept(method, path string, body io.Reader, accept string) (*http.Response, error) {
+func (c *Client) doRequestWithAccept(ctx context.Context, method, path string, body io.Reader, accept string) (*http.Response, error) {
+	header := http.Header{}
+	header.Set("Accept", accept)
+	return c.doRequestWithHeaders(ctx, method, path, body, header)
+}
+
+// doRequestWithHeaders performs a request with additional headers
+// Accept defaults to application/json, and Content-Type defaults to application/json when there is a body.
+func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string, body io.Reader, header http.Header) (*http.Response, error) {
 	endpoint, err := c.baseURL.Parse(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse endpoint path: %w", err)
 	}
 
-	req, err := http.NewRequest(method, endpoint.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Accept", accept)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	for name, values := range header {
+		req.Header[name] = values
 	}
 
-	if c.username != "" || c.password != "" {
-		req.SetBasicAuth(c.username, c.password)
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	makeReplayable(req, body)
+
+	resp, err := c.sendAuthorized(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -75,18 +101,14 @@ func (c *Client) doRequestWithAccept(method, path string, body io.Reader, accept
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		defer resp.Body.Close()
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, &HTTPError{
-			StatusCode: resp.StatusCode,
-			Status:     resp.Status,
-			Body:       string(bodyBytes),
-		}
+		return nil, newHTTPError(resp.StatusCode, resp.Status, resp.Header, bodyBytes)
 	}
 
 	return resp, nil
 }
 
-func (c *Client) get(path string, result interface{}) error {
-	resp, err := c.doRequest(http.MethodGet, path, nil)
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return err
 	}
@@ -99,7 +121,7 @@ func (c *Client) get(path string, result interface{}) error {
 	return nil
 }
 
-func (c *Client) post(path string, body interface{}, result interface{}) error {
+func (c *Client) post(ctx context.Context, path string, body interface{}, result interface{}) error {
 	var bodyReader io.Reader
 	if body != nil {
 		bodyBytes, err := json.Marshal(body)
@@ -109,7 +131,7 @@ func (c *Client) post(path string, body interface{}, result interface{}) error {
 		bodyReader = strings.NewReader(string(bodyBytes))
 	}
 
-	resp, err := c.doRequest(http.MethodPost, path, bodyReader)
+	resp, err := c.doRequest(ctx, http.MethodPost, path, bodyReader)
 	if err != nil {
 		return err
 	}
@@ -125,8 +147,8 @@ func (c *Client) post(path string, body interface{}, result interface{}) error {
 }
 
 // delete performs a DELETE request
-func (c *Client) delete(path string, result interface{}) error {
-	resp, err := c.doRequest(http.MethodDelete, path, nil)
+func (c *Client) delete(ctx context.Context, path string, result interface{}) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -142,7 +164,7 @@ func (c *Client) delete(path string, result interface{}) error {
 }
 
 // put performs a PUT request with JSON body
-func (c *Client) put(path string, body interface{}, result interface{}) error {
+func (c *Client) put(ctx context.Context, path string, body interface{}, result interface{}) error {
 	var bodyReader io.Reader
 	if body != nil {
 		bodyBytes, err := json.Marshal(body)
@@ -152,7 +174,7 @@ func (c *Client) put(path string, body interface{}, result interface{}) error {
 		bodyReader = strings.NewReader(string(bodyBytes))
 	}
 
-	resp, err := c.doRequest(http.MethodPut, path, bodyReader)
+	resp, err := c.doRequest(ctx, http.MethodPut, path, bodyReader)
 	if err != nil {
 		return err
 	}
@@ -23,7 +23,39 @@ func WithBasicAuth(username, password string) ClientOption {
 	}
 }
 
+// WithBearerToken sets a static token sent as a Bearer authorization header
+// It takes precedence over basic authentication credentials.
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) {
+		c.bearerToken = token
+	}
+}
+
+// WithTokenSource sets a source of Bearer tokens that are refreshed when they expire
+// A request rejected with 401 is retried once with a new token, provided its body can be replayed.
+// It takes precedence over WithBearerToken and basic authentication credentials.
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = &cachingTokenSource{source: source}
+	}
+}
+
+// WithHeaders sets static HTTP headers sent with every request
+// This is the client-side counterpart of the HttpHeaders of an Orthanc peer.
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(map[string]string, len(headers))
+		}
+		for name, value := range headers {
+			c.headers[name] = value
+		}
+	}
+}
+
 // WithTimeout sets the HTTP client timeout
+// The timeout also covers reading the response body, so for large archive
+// downloads prefer a zero timeout combined with per-call context deadlines.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
 		c.httpClient.Timeout = timeout
@@ -1,158 +1,169 @@
 package gorthanc
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"net/http"
-	"strconv"
+	"slices"
 
 	"github.com/proencaj/gorthanc/types"
 )
 
-func (c *Client) QidoSearchStudies(params *types.QidoStudyQueryParams) ([]map[string]interface{}, error) {
+func (c *Client) QidoSearchStudies(ctx context.Context, params *types.QidoStudyQueryParams) ([]types.DicomWebStudy, error) {
 	path := "dicom-web/studies"
 
 	if params != nil {
 		path = c.buildQidoStudiesPath(path, params)
 	}
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomWebStudy
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) QidoSearchSeries(studyUID string, params *types.QidoSeriesQueryParams) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series", studyUID)
+func (c *Client) QidoSearchSeries(ctx context.Context, studyUID string, params *types.QidoSeriesQueryParams) ([]types.DicomWebSeries, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series")
 
 	if params != nil {
 		path = c.buildQidoSeriesPath(path, params)
 	}
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomWebSeries
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) QidoSearchAllSeries(params *types.QidoSeriesQueryParams) ([]map[string]interface{}, error) {
+func (c *Client) QidoSearchAllSeries(ctx context.Context, params *types.QidoSeriesQueryParams) ([]types.DicomWebSeries, error) {
 	path := "dicom-web/series"
 
 	if params != nil {
 		path = c.buildQidoSeriesPath(path, params)
 	}
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomWebSeries
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) QidoSearchInstances(studyUID string, seriesUID string, params *types.QidoInstanceQueryParams) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s/instances", studyUID, seriesUID)
+func (c *Client) QidoSearchInstances(ctx context.Context, studyUID string, seriesUID string, params *types.QidoInstanceQueryParams) ([]types.DicomWebInstance, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "instances")
 
 	if params != nil {
 		path = c.buildQidoInstancesPath(path, params)
 	}
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomWebInstance
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) QidoSearchStudyInstances(studyUID string, params *types.QidoInstanceQueryParams) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/instances", studyUID)
+func (c *Client) QidoSearchStudyInstances(ctx context.Context, studyUID string, params *types.QidoInstanceQueryParams) ([]types.DicomWebInstance, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "instances")
 
 	if params != nil {
 		path = c.buildQidoInstancesPath(path, params)
 	}
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomWebInstance
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) QidoSearchAllInstances(params *types.QidoInstanceQueryParams) ([]map[string]interface{}, error) {
+func (c *Client) QidoSearchAllInstances(ctx context.Context, params *types.QidoInstanceQueryParams) ([]types.DicomWebInstance, error) {
 	path := "dicom-web/instances"
 
 	if params != nil {
 		path = c.buildQidoInstancesPath(path, params)
 	}
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomWebInstance
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) WadoRsRetrieveStudy(studyUID string) (*http.Response, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s", studyUID)
-	return c.getWithAcceptRawResponse(path, "multipart/related; type=application/dicom")
+// WadoRsRetrieveStudy retrieves all instances of a study as a multipart/related body.
+// Read the parts with NewMultipartReader, params selects the transfer syntax (optional).
+// The caller is responsible for closing the response body.
+func (c *Client) WadoRsRetrieveStudy(ctx context.Context, studyUID string, params *types.WadoRsRetrieveParams) (*http.Response, error) {
+	path := endpointPath("dicom-web", "studies", studyUID)
+	return c.getWithAcceptRawResponse(ctx, path, wadoRsAccept("application/dicom", params))
 }
 
-func (c *Client) WadoRsRetrieveSeries(studyUID string, seriesUID string) (*http.Response, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s", studyUID, seriesUID)
-	return c.getWithAcceptRawResponse(path, "multipart/related; type=application/dicom")
+// WadoRsRetrieveSeries retrieves all instances of a series as a multipart/related body.
+// The caller is responsible for closing the response body.
+func (c *Client) WadoRsRetrieveSeries(ctx context.Context, studyUID string, seriesUID string, params *types.WadoRsRetrieveParams) (*http.Response, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID)
+	return c.getWithAcceptRawResponse(ctx, path, wadoRsAccept("application/dicom", params))
 }
 
-func (c *Client) WadoRsRetrieveInstance(studyUID string, seriesUID string, instanceUID string) (*http.Response, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s/instances/%s", studyUID, seriesUID, instanceUID)
-	return c.getWithAcceptRawResponse(path, "multipart/related; type=application/dicom")
+// WadoRsRetrieveInstance retrieves a single instance as a multipart/related body.
+// The caller is responsible for closing the response body.
+func (c *Client) WadoRsRetrieveInstance(ctx context.Context, studyUID string, seriesUID string, instanceUID string, params *types.WadoRsRetrieveParams) (*http.Response, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "instances", instanceUID)
+	return c.getWithAcceptRawResponse(ctx, path, wadoRsAccept("application/dicom", params))
 }
 
-func (c *Client) WadoRsRetrieveStudyMetadata(studyUID string) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/metadata", studyUID)
+func (c *Client) WadoRsRetrieveStudyMetadata(ctx context.Context, studyUID string) ([]types.DicomJSONDataset, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "metadata")
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomJSONDataset
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) WadoRsRetrieveSeriesMetadata(studyUID string, seriesUID string) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s/metadata", studyUID, seriesUID)
+func (c *Client) WadoRsRetrieveSeriesMetadata(ctx context.Context, studyUID string, seriesUID string) ([]types.DicomJSONDataset, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "metadata")
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomJSONDataset
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) WadoRsRetrieveInstanceMetadata(studyUID string, seriesUID string, instanceUID string) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s/instances/%s/metadata", studyUID, seriesUID, instanceUID)
+func (c *Client) WadoRsRetrieveInstanceMetadata(ctx context.Context, studyUID string, seriesUID string, instanceUID string) ([]types.DicomJSONDataset, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "instances", instanceUID, "metadata")
 
-	var results []map[string]interface{}
-	if err := c.get(path, &results); err != nil {
+	var results []types.DicomJSONDataset
+	if err := c.get(ctx, path, &results); err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
 
-func (c *Client) WadoRsRetrieveFrames(studyUID string, seriesUID string, instanceUID string, frameList string) (*http.Response, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s/instances/%s/frames/%s", studyUID, seriesUID, instanceUID, frameList)
-	return c.getWithAcceptRawResponse(path, "multipart/related; type=application/octet-stream")
+// WadoRsRetrieveFrames retrieves frames of an instance as a multipart/related body.
+// The caller is responsible for closing the response body.
+func (c *Client) WadoRsRetrieveFrames(ctx context.Context, studyUID string, seriesUID string, instanceUID string, frameList string, params *types.WadoRsRetrieveParams) (*http.Response, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "instances", instanceUID, "frames", frameList)
+	return c.getWithAcceptRawResponse(ctx, path, wadoRsAccept("application/octet-stream", params))
 }
 
-func (c *Client) WadoRsRetrieveRenderedInstance(studyUID string, seriesUID string, instanceUID string, params *types.WadoRsRenderedParams) (*http.Response, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s/instances/%s/rendered", studyUID, seriesUID, instanceUID)
+func (c *Client) WadoRsRetrieveRenderedInstance(ctx context.Context, studyUID string, seriesUID string, instanceUID string, params *types.WadoRsRenderedParams) (*http.Response, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "instances", instanceUID, "rendered")
 
 	accept := "image/jpeg"
 	if params != nil {
@@ -162,11 +173,11 @@ func (c *Client) WadoRsRetrieveRenderedInstance(studyUID string, seriesUID strin
 		}
 	}
 
-	return c.getWithAcceptRawResponse(path, accept)
+	return c.getWithAcceptRawResponse(ctx, path, accept)
 }
 
-func (c *Client) WadoRsRetrieveRenderedFrames(studyUID string, seriesUID string, instanceUID string, frameList string, params *types.WadoRsRenderedParams) (*http.Response, error) {
-	path := fmt.Sprintf("dicom-web/studies/%s/series/%s/instances/%s/frames/%s/rendered", studyUID, seriesUID, instanceUID, frameList)
+func (c *Client) WadoRsRetrieveRenderedFrames(ctx context.Context, studyUID string, seriesUID string, instanceUID string, frameList string, params *types.WadoRsRenderedParams) (*http.Response, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "instances", instanceUID, "frames", frameList, "rendered")
 
 	accept := "image/jpeg"
 	if params != nil {
@@ -176,16 +187,16 @@ func (c *Client) WadoRsRetrieveRenderedFrames(studyUID string, seriesUID string,
 		}
 	}
 
-	return c.getWithAcceptRawResponse(path, accept)
+	return c.getWithAcceptRawResponse(ctx, path, accept)
 }
 
-func (c *Client) WadoUriRetrieve(params *types.WadoUriParams) (*http.Response, error) {
+func (c *Client) WadoUriRetrieve(ctx context.Context, params *types.WadoUriParams) (*http.Response, error) {
 	if params == nil {
 		return nil, fmt.Errorf("WadoUriParams is required")
 	}
 
 	path := c.buildWadoUriPath("wado", params)
-	return c.getWithRawResponse(path)
+	return c.getWithRawResponse(ctx, path)
 }
 
 func (c *Client) buildQidoStudiesPath(basePath string, params *types.QidoStudyQueryParams) string {
@@ -193,60 +204,14 @@ func (c *Client) buildQidoStudiesPath(basePath string, params *types.QidoStudyQu
 		return basePath
 	}
 
-	queryParams := ""
-	separator := "?"
-
-	if params.Limit > 0 {
-		queryParams += separator + "limit=" + strconv.Itoa(params.Limit)
-		separator = "&"
-	}
-
-	if params.Offset > 0 {
-		queryParams += separator + "offset=" + strconv.Itoa(params.Offset)
-		separator = "&"
-	}
-
-	if params.Includefield != "" {
-		queryParams += separator + "includefield=" + params.Includefield
-		separator = "&"
-	}
-
-	if params.FuzzyMatching {
-		queryParams += separator + "fuzzymatching=true"
-		separator = "&"
-	}
-
-	if params.StudyInstanceUID != "" {
-		queryParams += separator + "StudyInstanceUID=" + params.StudyInstanceUID
-		separator = "&"
-	}
-
-	if params.PatientID != "" {
-		queryParams += separator + "PatientID=" + params.PatientID
-		separator = "&"
-	}
-
-	if params.PatientName != "" {
-		queryParams += separator + "PatientName=" + params.PatientName
-		separator = "&"
-	}
-
-	if params.AccessionNumber != "" {
-		queryParams += separator + "AccessionNumber=" + params.AccessionNumber
-		separator = "&"
-	}
-
-	if params.StudyDate != "" {
-		queryParams += separator + "StudyDate=" + params.StudyDate
-		separator = "&"
-	}
-
-	if params.ModalitiesInStudy != "" {
-		queryParams += separator + "ModalitiesInStudy=" + params.ModalitiesInStudy
-		separator = "&"
-	}
-
-	return basePath + queryParams
+	return basePath + qidoQuery(params.QidoQueryParams, map[string]string{
+		"StudyInstanceUID":  params.StudyInstanceUID,
+		"PatientID":         params.PatientID,
+		"PatientName":       params.PatientName,
+		"AccessionNumber":   params.AccessionNumber,
+		"StudyDate":         params.StudyDate,
+		"ModalitiesInStudy": params.ModalitiesInStudy,
+	})
 }
 
 func (c *Client) buildQidoSeriesPath(basePath string, params *types.QidoSeriesQueryParams) string {
@@ -254,50 +219,12 @@ func (c *Client) buildQidoSeriesPath(basePath string, params *types.QidoSeriesQu
 		return basePath
 	}
 
-	queryParams := ""
-	separator := "?"
-
-	if params.Limit > 0 {
-		queryParams += separator + "limit=" + strconv.Itoa(params.Limit)
-		separator = "&"
-	}
-
-	if params.Offset > 0 {
-		queryParams += separator + "offset=" + strconv.Itoa(params.Offset)
-		separator = "&"
-	}
-
-	if params.Includefield != "" {
-		queryParams += separator + "includefield=" + params.Includefield
-		separator = "&"
-	}
-
-	if params.FuzzyMatching {
-		queryParams += separator + "fuzzymatching=true"
-		separator = "&"
-	}
-
-	if params.StudyInstanceUID != "" {
-		queryParams += separator + "StudyInstanceUID=" + params.StudyInstanceUID
-		separator = "&"
-	}
-
-	if params.SeriesInstanceUID != "" {
-		queryParams += separator + "SeriesInstanceUID=" + params.SeriesInstanceUID
-		separator = "&"
-	}
-
-	if params.Modality != "" {
-		queryParams += separator + "Modality=" + params.Modality
-		separator = "&"
-	}
-
-	if params.SeriesNumber != "" {
-		queryParams += separator + "SeriesNumber=" + params.SeriesNumber
-		separator = "&"
-	}
-
-	return basePath + queryParams
+	return basePath + qidoQuery(params.QidoQueryParams, map[string]string{
+		"StudyInstanceUID":  params.StudyInstanceUID,
+		"SeriesInstanceUID": params.SeriesInstanceUID,
+		"Modality":          params.Modality,
+		"SeriesNumber":      params.SeriesNumber,
+	})
 }
 
 func (c *Client) buildQidoInstancesPath(basePath string, params *types.QidoInstanceQueryParams) string {
@@ -305,50 +232,46 @@ func (c *Client) buildQidoInstancesPath(basePath string, params *types.QidoInsta
 		return basePath
 	}
 
-	queryParams := ""
-	separator := "?"
+	return basePath + qidoQuery(params.QidoQueryParams, map[string]string{
+		"StudyInstanceUID":  params.StudyInstanceUID,
+		"SeriesInstanceUID": params.SeriesInstanceUID,
+		"SOPInstanceUID":    params.SOPInstanceUID,
+		"SOPClassUID":       params.SOPClassUID,
+	})
+}
 
-	if params.Limit > 0 {
-		queryParams += separator + "limit=" + strconv.Itoa(params.Limit)
-		separator = "&"
-	}
+// qidoQuery builds the query string of a QIDO-RS request
+// Empty filters are skipped, while empty Match values are kept to request the attribute.
+func qidoQuery(common types.QidoQueryParams, filters map[string]string) string {
+	var query queryBuilder
 
-	if params.Offset > 0 {
-		queryParams += separator + "offset=" + strconv.Itoa(params.Offset)
-		separator = "&"
+	if common.Limit > 0 {
+		query.addInt("limit", common.Limit)
 	}
 
-	if params.Includefield != "" {
-		queryParams += separator + "includefield=" + params.Includefield
-		separator = "&"
+	if common.Offset > 0 {
+		query.addInt("offset", common.Offset)
 	}
 
-	if params.FuzzyMatching {
-		queryParams += separator + "fuzzymatching=true"
-		separator = "&"
+	for _, field := range common.Includefield {
+		query.add("includefield", field)
 	}
 
-	if params.StudyInstanceUID != "" {
-		queryParams += separator + "StudyInstanceUID=" + params.StudyInstanceUID
-		separator = "&"
+	if common.FuzzyMatching {
+		query.add("fuzzymatching", "true")
 	}
 
-	if params.SeriesInstanceUID != "" {
-		queryParams += separator + "SeriesInstanceUID=" + params.SeriesInstanceUID
-		separator = "&"
-	}
-
-	if params.SOPInstanceUID != "" {
-		queryParams += separator + "SOPInstanceUID=" + params.SOPInstanceUID
-		separator = "&"
+	for _, attribute := range slices.Sorted(maps.Keys(filters)) {
+		if _, overridden := common.Match[attribute]; filters[attribute] != "" && !overridden {
+			query.add(attribute, filters[attribute])
+		}
 	}
 
-	if params.SOPClassUID != "" {
-		queryParams += separator + "SOPClassUID=" + params.SOPClassUID
-		separator = "&"
+	for _, attribute := range slices.Sorted(maps.Keys(common.Match)) {
+		query.add(attribute, common.Match[attribute])
 	}
 
-	return basePath + queryParams
+	return query.encode()
 }
 
 func (c *Client) buildWadoRsRenderedPath(basePath string, params *types.WadoRsRenderedParams) string {
@@ -356,30 +279,25 @@ func (c *Client) buildWadoRsRenderedPath(basePath string, params *types.WadoRsRe
 		return basePath
 	}
 
-	queryParams := ""
-	separator := "?"
+	var query queryBuilder
 
 	if params.WindowCenter != "" {
-		queryParams += separator + "window-center=" + params.WindowCenter
-		separator = "&"
+		query.add("window-center", params.WindowCenter)
 	}
 
 	if params.WindowWidth != "" {
-		queryParams += separator + "window-width=" + params.WindowWidth
-		separator = "&"
+		query.add("window-width", params.WindowWidth)
 	}
 
 	if params.Quality > 0 {
-		queryParams += separator + "quality=" + strconv.Itoa(params.Quality)
-		separator = "&"
+		query.addInt("quality", params.Quality)
 	}
 
 	if params.Viewport != "" {
-		queryParams += separator + "viewport=" + params.Viewport
-		separator = "&"
+		query.add("viewport", params.Viewport)
 	}
 
-	return basePath + queryParams
+	return basePath + query.encode()
 }
 
 func (c *Client) buildWadoUriPath(basePath string, params *types.WadoUriParams) string {
@@ -387,65 +305,65 @@ func (c *Client) buildWadoUriPath(basePath string, params *types.WadoUriParams)
 		return basePath
 	}
 
-	queryParams := "?"
+	var query queryBuilder
 
 	requestType := params.RequestType
 	if requestType == "" {
 		requestType = "WADO"
 	}
-	queryParams += "requestType=" + requestType
+	query.add("requestType", requestType)
 
 	if params.StudyUID != "" {
-		queryParams += "&studyUID=" + params.StudyUID
+		query.add("studyUID", params.StudyUID)
 	}
 
 	if params.SeriesUID != "" {
-		queryParams += "&seriesUID=" + params.SeriesUID
+		query.add("seriesUID", params.SeriesUID)
 	}
 
 	if params.ObjectUID != "" {
-		queryParams += "&objectUID=" + params.ObjectUID
+		query.add("objectUID", params.ObjectUID)
 	}
 
 	if params.ContentType != "" {
-		queryParams += "&contentType=" + params.ContentType
+		query.add("contentType", params.ContentType)
 	}
 
 	if params.TransferSyntax != "" {
-		queryParams += "&transferSyntax=" + params.TransferSyntax
+		query.add("transferSyntax", params.TransferSyntax)
 	}
 
 	if params.Anonymize != "" {
-		queryParams += "&anonymize=" + params.Anonymize
+		query.add("anonymize", params.Anonymize)
 	}
 
 	if params.FrameNumber > 0 {
-		queryParams += "&frameNumber=" + strconv.Itoa(params.FrameNumber)
+		query.addInt("frameNumber", params.FrameNumber)
 	}
 
 	if params.ImageQuality > 0 {
-		queryParams += "&imageQuality=" + strconv.Itoa(params.ImageQuality)
+		query.addInt("imageQuality", params.ImageQuality)
 	}
 
 	if params.WindowCenter != "" {
-		queryParams += "&windowCenter=" + params.WindowCenter
+		query.add("windowCenter", params.WindowCenter)
 	}
 
 	if params.WindowWidth != "" {
-		queryParams += "&windowWidth=" + params.WindowWidth
+		query.add("windowWidth", params.WindowWidth)
 	}
 
 	if params.Rows > 0 {
-		queryParams += "&rows=" + strconv.Itoa(params.Rows)
+		query.addInt("rows", params.Rows)
 	}
 
 	if params.Columns > 0 {
-		queryParams += "&columns=" + strconv.Itoa(params.Columns)
+		query.addInt("columns", params.Columns)
 	}
 
 	if params.Region != "" {
-		queryParams += "&region=" + params.Region
+		query.add("region", params.Region)
 	}
 
-	return basePath + queryParams
+	return basePath + query.encode()
 }
@@ -0,0 +1,226 @@
+package gorthanc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// MultipartPart is a single part of a multipart/related response
+type MultipartPart struct {
+	// Headers of the part
+	Header textproto.MIMEHeader
+
+	// Content-Type of the part (e.g., "application/dicom")
+	ContentType string
+
+	// Content-Location of the part, empty if the server did not provide it
+	ContentLocation string
+
+	// Content of the part, only valid until the next part is requested
+	Body io.Reader
+}
+
+// MultipartReader reads the parts of a multipart/related response one at a time, without buffering them
+type MultipartReader struct {
+	body   io.ReadCloser
+	reader *multipart.Reader
+}
+
+// NewMultipartReader creates a reader of the multipart/related body of a WADO-RS response,
+// such as the ones returned by WadoRsRetrieveStudy or WadoRsRetrieveFrames.
+// Closing the reader closes the response body.
+func NewMultipartReader(resp *http.Response) (*MultipartReader, error) {
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse content type: %w", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("expected a multipart response, got %q", mediaType)
+	}
+
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, fmt.Errorf("missing boundary in multipart response")
+	}
+
+	return &MultipartReader{
+		body:   resp.Body,
+		reader: multipart.NewReader(resp.Body, boundary),
+	}, nil
+}
+
+// NextPart returns the next part, or io.EOF once all the parts were read
+func (r *MultipartReader) NextPart() (*MultipartPart, error) {
+	part, err := r.reader.NextRawPart()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultipartPart{
+		Header:          part.Header,
+		ContentType:     part.Header.Get("Content-Type"),
+		ContentLocation: part.Header.Get("Content-Location"),
+		Body:            part,
+	}, nil
+}
+
+// Parts iterates over the remaining parts
+// Iteration stops after the first error, which is yielded with a nil part.
+func (r *MultipartReader) Parts() iter.Seq2[*MultipartPart, error] {
+	return func(yield func(*MultipartPart, error) bool) {
+		for {
+			part, err := r.NextPart()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(part, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Close closes the underlying response body
+func (r *MultipartReader) Close() error {
+	return r.body.Close()
+}
+
+// wadoRsAccept builds the Accept header of a WADO-RS multipart request
+func wadoRsAccept(partType string, params *types.WadoRsRetrieveParams) string {
+	accept := fmt.Sprintf(`multipart/related; type="%s"`, partType)
+	if params != nil && params.TransferSyntax != "" {
+		accept += "; transfer-syntax=" + params.TransferSyntax
+	}
+	return accept
+}
+
+// WadoRsSaveStudy retrieves all the instances of a study and saves them as files in dir
+// Files are named after the SOP Instance UID ending their Content-Location when available.
+// Returns the number of files written.
+func (c *Client) WadoRsSaveStudy(ctx context.Context, studyUID string, dir string, params *types.WadoRsRetrieveParams) (int, error) {
+	path := endpointPath("dicom-web", "studies", studyUID)
+	return c.wadoRsSaveInstances(ctx, path, dir, params)
+}
+
+// WadoRsSaveSeries retrieves all the instances of a series and saves them as files in dir
+// See WadoRsSaveStudy.
+func (c *Client) WadoRsSaveSeries(ctx context.Context, studyUID string, seriesUID string, dir string, params *types.WadoRsRetrieveParams) (int, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID)
+	return c.wadoRsSaveInstances(ctx, path, dir, params)
+}
+
+// WadoRsGetFrames retrieves frames of an instance, in the order of frameList (e.g. "1,3,5")
+func (c *Client) WadoRsGetFrames(ctx context.Context, studyUID string, seriesUID string, instanceUID string, frameList string, params *types.WadoRsRetrieveParams) ([][]byte, error) {
+	path := endpointPath("dicom-web", "studies", studyUID, "series", seriesUID, "instances", instanceUID, "frames", frameList)
+
+	resp, err := c.getWithAcceptRawResponse(ctx, path, wadoRsAccept("application/octet-stream", params))
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := NewMultipartReader(resp)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	defer reader.Close()
+
+	var frames [][]byte
+	for part, err := range reader.Parts() {
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart response: %w", err)
+		}
+
+		var frame bytes.Buffer
+		if _, err := io.Copy(&frame, part.Body); err != nil {
+			return nil, fmt.Errorf("failed to read frame: %w", err)
+		}
+		frames = append(frames, frame.Bytes())
+	}
+
+	return frames, nil
+}
+
+// wadoRsSaveInstances streams the instances of a WADO-RS response to files in dir
+func (c *Client) wadoRsSaveInstances(ctx context.Context, endpoint string, dir string, params *types.WadoRsRetrieveParams) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	resp, err := c.getWithAcceptRawResponse(ctx, endpoint, wadoRsAccept("application/dicom", params))
+	if err != nil {
+		return 0, err
+	}
+
+	reader, err := NewMultipartReader(resp)
+	if err != nil {
+		resp.Body.Close()
+		return 0, err
+	}
+	defer reader.Close()
+
+	count := 0
+	for part, err := range reader.Parts() {
+		if err != nil {
+			return count, fmt.Errorf("failed to read multipart response: %w", err)
+		}
+
+		if err := writePartToFile(part, filepath.Join(dir, instanceFileName(part.ContentLocation, count+1))); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// sopInstanceUIDPattern matches the characters allowed in a DICOM UID
+var sopInstanceUIDPattern = regexp.MustCompile(`^[0-9.]+$`)
+
+// instanceFileName names the file of the index-th instance of a WADO-RS response
+// The SOP Instance UID at the end of the Content-Location is only used when it is a valid UID,
+// so that a server cannot write outside the target directory.
+func instanceFileName(contentLocation string, index int) string {
+	base := path.Base(strings.TrimSuffix(contentLocation, "/"))
+	if base != "." && sopInstanceUIDPattern.MatchString(base) && filepath.IsLocal(base) {
+		return base + ".dcm"
+	}
+	return fmt.Sprintf("instance_%04d.dcm", index)
+}
+
+// writePartToFile writes the content of a part to a file
+func writePartToFile(part *MultipartPart, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if _, err := io.Copy(file, part.Body); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -1,34 +1,38 @@
 package gorthanc
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/proencaj/gorthanc/types"
 )
 
 
-func (c *Client) GetDicomWebServers() ([]string, error) {
+func (c *Client) GetDicomWebServers(ctx context.Context) ([]string, error) {
 	var servers []string
-	if err := c.get("dicom-web/servers", &servers); err != nil {
+	if err := c.get(ctx, "dicom-web/servers", &servers); err != nil {
 		return nil, err
 	}
 	return servers, nil
 }
 
 
-func (c *Client) GetDicomWebServersExpanded() (map[string]types.DicomWebServer, error) {
+func (c *Client) GetDicomWebServersExpanded(ctx context.Context) (map[string]types.DicomWebServer, error) {
 	var servers map[string]types.DicomWebServer
-	if err := c.get("dicom-web/servers?expand=true", &servers); err != nil {
+	if err := c.get(ctx, "dicom-web/servers?expand=true", &servers); err != nil {
 		return nil, err
 	}
 	return servers, nil
 }
 
 
-func (c *Client) CreateOrUpdateDicomWebServer(serverName string, request *types.DicomWebServerCreateRequest) error {
-	path := fmt.Sprintf("dicom-web/servers/%s", serverName)
+func (c *Client) CreateOrUpdateDicomWebServer(ctx context.Context, serverName string, request *types.DicomWebServerCreateRequest) error {
+	path := endpointPath("dicom-web", "servers", serverName)
 
-	if err := c.put(path, request, nil); err != nil {
+	if err := c.put(ctx, path, request, nil); err != nil {
 		return err
 	}
 
@@ -36,13 +40,116 @@ func (c *Client) CreateOrUpdateDicomWebServer(serverName string, request *types.
 }
 
 
-func (c *Client) DeleteDicomWebServer(serverName string) error {
-	path := fmt.Sprintf("dicom-web/servers/%s", serverName)
+func (c *Client) DeleteDicomWebServer(ctx context.Context, serverName string) error {
+	path := endpointPath("dicom-web", "servers", serverName)
 
-	if err := c.delete(path, nil); err != nil {
+	if err := c.delete(ctx, path, nil); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+
+// StoreToDicomWebServer sends local resources to a remote DICOMweb server through STOW-RS and waits for the transfer
+// This endpoint implements the POST /dicom-web/servers/{id}/stow request
+func (c *Client) StoreToDicomWebServer(ctx context.Context, serverName string, request *types.DicomWebServerStowRequest) error {
+	if request == nil {
+		return fmt.Errorf("DicomWebServerStowRequest is required")
+	}
+
+	path := endpointPath("dicom-web", "servers", serverName, "stow")
+	stowRequest := *request
+	stowRequest.Synchronous = BoolPtr(true)
+
+	return c.post(ctx, path, &stowRequest, nil)
+}
+
+
+// StoreToDicomWebServerAsync sends local resources to a remote DICOMweb server as a job
+// This endpoint implements the POST /dicom-web/servers/{id}/stow request in asynchronous mode
+func (c *Client) StoreToDicomWebServerAsync(ctx context.Context, serverName string, request *types.DicomWebServerStowRequest) (*JobHandle, error) {
+	if request == nil {
+		return nil, fmt.Errorf("DicomWebServerStowRequest is required")
+	}
+
+	path := endpointPath("dicom-web", "servers", serverName, "stow")
+	stowRequest := *request
+	stowRequest.Synchronous = BoolPtr(false)
+
+	return c.submitJob(ctx, path, &stowRequest)
+}
+
+
+// GetFromDicomWebServer forwards a GET request to a remote DICOMweb server, returning its raw response
+// The caller is responsible for closing the response body.
+// This endpoint implements the POST /dicom-web/servers/{id}/get request
+func (c *Client) GetFromDicomWebServer(ctx context.Context, serverName string, request *types.DicomWebServerGetRequest) (*http.Response, error) {
+	path := endpointPath("dicom-web", "servers", serverName, "get")
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	// The remote server decides the content type of the answer
+	return c.doRequestWithAccept(ctx, http.MethodPost, path, bytes.NewReader(body), "*/*")
+}
+
+
+// QueryDicomWebServer runs a QIDO-RS query against a remote DICOMweb server
+// This endpoint implements the POST /dicom-web/servers/{id}/qido request
+func (c *Client) QueryDicomWebServer(ctx context.Context, serverName string, request *types.DicomWebServerGetRequest) ([]types.DicomJSONDataset, error) {
+	path := endpointPath("dicom-web", "servers", serverName, "qido")
+
+	var results []types.DicomJSONDataset
+	if err := c.post(ctx, path, request, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+
+// RetrieveFromDicomWebServer pulls resources from a remote DICOMweb server into Orthanc through WADO-RS
+// This endpoint implements the POST /dicom-web/servers/{id}/retrieve request
+func (c *Client) RetrieveFromDicomWebServer(ctx context.Context, serverName string, request *types.DicomWebServerRetrieveRequest) (*types.DicomWebServerRetrieveResult, error) {
+	if request == nil {
+		return nil, fmt.Errorf("DicomWebServerRetrieveRequest is required")
+	}
+
+	path := endpointPath("dicom-web", "servers", serverName, "retrieve")
+	retrieveRequest := *request
+	retrieveRequest.Synchronous = BoolPtr(true)
+
+	var result types.DicomWebServerRetrieveResult
+	if err := c.post(ctx, path, &retrieveRequest, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+
+// RetrieveFromDicomWebServerAsync pulls resources from a remote DICOMweb server into Orthanc as a job
+// This endpoint implements the POST /dicom-web/servers/{id}/retrieve request in asynchronous mode
+func (c *Client) RetrieveFromDicomWebServerAsync(ctx context.Context, serverName string, request *types.DicomWebServerRetrieveRequest) (*JobHandle, error) {
+	if request == nil {
+		return nil, fmt.Errorf("DicomWebServerRetrieveRequest is required")
+	}
+
+	path := endpointPath("dicom-web", "servers", serverName, "retrieve")
+	retrieveRequest := *request
+	retrieveRequest.Synchronous = BoolPtr(false)
+
+	return c.submitJob(ctx, path, &retrieveRequest)
+}
+
+
+// DeleteFromDicomWebServer deletes resources on a remote DICOMweb server
+// The server must be declared with HasDelete enabled.
+// This endpoint implements the POST /dicom-web/servers/{id}/delete request
+func (c *Client) DeleteFromDicomWebServer(ctx context.Context, serverName string, request *types.DicomWebServerDeleteRequest) error {
+	path := endpointPath("dicom-web", "servers", serverName, "delete")
+	return c.post(ctx, path, request, nil)
+}
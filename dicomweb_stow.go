@@ -0,0 +1,114 @@
+package gorthanc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// DICOM tags found in a STOW-RS response
+const (
+	tagFailedSOPSequence     = "00081198"
+	tagReferencedSOPSequence = "00081199"
+	tagReferencedSOPClass    = "00081150"
+	tagReferencedSOPInstance = "00081155"
+	tagFailureReason         = "00081197"
+	tagWarningReason         = "00081196"
+)
+
+// StowRsStore stores DICOM instances through STOW-RS
+// This endpoint implements the POST /dicom-web/studies[/{study}] request
+// The instances are streamed as a multipart/related body without being buffered in memory,
+// so the request cannot be retried. Pass an empty studyUID to store instances of any study.
+// If Orthanc rejects every instance, the parsed response is returned along with the error.
+func (c *Client) StowRsStore(ctx context.Context, studyUID string, files ...io.Reader) (*types.StowRsResponse, error) {
+	path := "dicom-web/studies"
+	if studyUID != "" {
+		path = endpointPath("dicom-web", "studies", studyUID)
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeStowRsBody(writer, files))
+	}()
+
+	header := http.Header{}
+	header.Set("Accept", "application/dicom+json")
+	header.Set("Content-Type", fmt.Sprintf(`multipart/related; type="application/dicom"; boundary=%s`, writer.Boundary()))
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodPost, path, pr, header)
+	if err != nil {
+		pr.CloseWithError(err)
+
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict {
+			if result, decodeErr := decodeStowRsResponse([]byte(httpErr.Body)); decodeErr == nil {
+				return result, err
+			}
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return decodeStowRsResponse(body)
+}
+
+// writeStowRsBody writes every file as an application/dicom part
+func writeStowRsBody(writer *multipart.Writer, files []io.Reader) error {
+	for _, file := range files {
+		partHeader := textproto.MIMEHeader{}
+		partHeader.Set("Content-Type", "application/dicom")
+
+		part, err := writer.CreatePart(partHeader)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file); err != nil {
+			return fmt.Errorf("failed to write multipart body: %w", err)
+		}
+	}
+	return writer.Close()
+}
+
+// decodeStowRsResponse parses the DICOM JSON response of a STOW-RS request
+func decodeStowRsResponse(body []byte) (*types.StowRsResponse, error) {
+	var dataset types.DicomJSONDataset
+	if err := json.Unmarshal(body, &dataset); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	result := &types.StowRsResponse{RetrieveURL: dataset.String(types.TagRetrieveURL)}
+
+	for _, item := range dataset.Sequence(tagReferencedSOPSequence) {
+		result.Stored = append(result.Stored, stowRsInstanceResult(item))
+	}
+	for _, item := range dataset.Sequence(tagFailedSOPSequence) {
+		result.Failed = append(result.Failed, stowRsInstanceResult(item))
+	}
+
+	return result, nil
+}
+
+func stowRsInstanceResult(item types.DicomJSONDataset) types.StowRsInstanceResult {
+	return types.StowRsInstanceResult{
+		SOPClassUID:    item.String(tagReferencedSOPClass),
+		SOPInstanceUID: item.String(tagReferencedSOPInstance),
+		RetrieveURL:    item.String(types.TagRetrieveURL),
+		FailureReason:  item.Int(tagFailureReason),
+		WarningReason:  item.Int(tagWarningReason),
+	}
+}
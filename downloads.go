@@ -0,0 +1,303 @@
+package gorthanc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// DownloadOptions configures how an archive is saved
+type DownloadOptions struct {
+	// Called after every chunk written with the number of bytes written so far
+	// and the total size, or -1 if the server did not announce it (optional)
+	OnProgress func(written, total int64)
+
+	// Hash computed over the whole downloaded content, e.g. sha256.New() (optional)
+	Hash hash.Hash
+
+	// Expected hex-encoded value of Hash, the download fails if it does not match (optional)
+	ExpectedChecksum string
+
+	// Resume an interrupted download, appending to the existing file (SaveArchiveToFile only)
+	// The download restarts from scratch if the server does not support HTTP Range requests.
+	Resume bool
+}
+
+// DownloadResult describes a completed download
+type DownloadResult struct {
+	// Number of bytes written during this call
+	Written int64
+
+	// Total size of the content, including the resumed part, or -1 if unknown
+	Total int64
+
+	// Whether the download continued a previous partial download
+	Resumed bool
+
+	// Hex-encoded value of DownloadOptions.Hash, empty if no hash was requested
+	Checksum string
+}
+
+// progressWriter counts the bytes written and reports the progress
+type progressWriter struct {
+	w          io.Writer
+	written    int64
+	offset     int64
+	total      int64
+	onProgress func(written, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.onProgress != nil {
+		p.onProgress(p.offset+p.written, p.total)
+	}
+	return n, err
+}
+
+// archivePath returns the path of the archive of a resource in the given format
+func archivePath(level types.ResourceLevel, resourceID string, format types.ArchiveFormat) (string, error) {
+	if level == types.ResourceLevelInstance {
+		return "", fmt.Errorf("archives are not available at the instance level")
+	}
+	if format != types.ArchiveFormatZip && format != types.ArchiveFormatMedia {
+		return "", fmt.Errorf("unknown archive format: %q", format)
+	}
+
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return "", err
+	}
+
+	return path + "/" + string(format), nil
+}
+
+// SaveArchive streams the archive of a patient, study or series to w
+// Unlike the Download*Archive and Download*Media methods, it reports progress and verifies checksums.
+// The archive is generated on the fly by Orthanc, so prefer a zero client timeout
+// and a context deadline for large resources.
+func (c *Client) SaveArchive(ctx context.Context, level types.ResourceLevel, resourceID string, format types.ArchiveFormat, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+	path, err := archivePath(level, resourceID, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.saveDownload(ctx, path, w, opts)
+}
+
+// SaveArchiveToFile downloads the archive of a patient, study or series to a file
+// With opts.Resume, an existing file is treated as a partial download and completed using
+// an HTTP Range request, a file that is already complete is left as is. Otherwise the file is overwritten.
+func (c *Client) SaveArchiveToFile(ctx context.Context, level types.ResourceLevel, resourceID string, format types.ArchiveFormat, filePath string, opts *DownloadOptions) (*DownloadResult, error) {
+	path, err := archivePath(level, resourceID, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.saveDownloadToFile(ctx, path, filePath, opts)
+}
+
+// saveDownload streams the content of a GET request to w
+func (c *Client) saveDownload(ctx context.Context, path string, w io.Writer, opts *DownloadOptions) (*DownloadResult, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+
+	resp, err := c.getWithAcceptRawResponse(ctx, path, "*/*")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return copyDownload(resp, w, 0, opts)
+}
+
+// saveDownloadToFile downloads the content of a GET request to a file, resuming it if requested
+func (c *Client) saveDownloadToFile(ctx context.Context, path string, filePath string, opts *DownloadOptions) (*DownloadResult, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output file: %w", err)
+	}
+	defer file.Close()
+
+	var offset int64
+	if opts.Resume {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat output file: %w", err)
+		}
+		offset = info.Size()
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "*/*")
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodGet, path, nil, header)
+	if offset > 0 && err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			if contentRangeSize(httpErr.Header) == offset {
+				// The file was already downloaded completely
+				return completedDownload(file, offset, opts)
+			}
+
+			// The partial file does not match the remote content, start over
+			header.Del("Range")
+			offset = 0
+			resp, err = c.doRequestWithHeaders(ctx, http.MethodGet, path, nil, header)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if offset > 0 && (resp.StatusCode != http.StatusPartialContent || contentRangeStart(resp) != offset) {
+		// The server ignored the Range header and sent the whole content
+		offset = 0
+	}
+
+	if offset > 0 {
+		if err := hashPartialDownload(file, offset, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := file.Truncate(offset); err != nil {
+		return nil, fmt.Errorf("failed to truncate output file: %w", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek output file: %w", err)
+	}
+
+	return copyDownload(resp, file, offset, opts)
+}
+
+// hashPartialDownload hashes the part downloaded previously so that the checksum covers the whole file
+func hashPartialDownload(file *os.File, offset int64, opts *DownloadOptions) error {
+	if opts.Hash == nil {
+		return nil
+	}
+
+	opts.Hash.Reset()
+	if _, err := io.Copy(opts.Hash, io.NewSectionReader(file, 0, offset)); err != nil {
+		return fmt.Errorf("failed to read partial download: %w", err)
+	}
+	return nil
+}
+
+// completedDownload describes a resumed download whose file already holds the whole content
+func completedDownload(file *os.File, size int64, opts *DownloadOptions) (*DownloadResult, error) {
+	if err := hashPartialDownload(file, size, opts); err != nil {
+		return nil, err
+	}
+
+	if opts.OnProgress != nil {
+		opts.OnProgress(size, size)
+	}
+
+	result := &DownloadResult{
+		Total:   size,
+		Resumed: true,
+	}
+	return result, verifyChecksum(result, opts)
+}
+
+// copyDownload copies a response body to w, reporting progress and computing the checksum
+func copyDownload(resp *http.Response, w io.Writer, offset int64, opts *DownloadOptions) (*DownloadResult, error) {
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+
+	dst := w
+	if opts.Hash != nil {
+		if offset == 0 {
+			opts.Hash.Reset()
+		}
+		dst = io.MultiWriter(w, opts.Hash)
+	}
+
+	pw := &progressWriter{w: dst, offset: offset, total: total, onProgress: opts.OnProgress}
+	if _, err := io.Copy(pw, resp.Body); err != nil {
+		return nil, fmt.Errorf("failed to download content: %w", err)
+	}
+
+	result := &DownloadResult{
+		Written: pw.written,
+		Total:   total,
+		Resumed: offset > 0,
+	}
+
+	return result, verifyChecksum(result, opts)
+}
+
+// verifyChecksum sets the checksum of a download and compares it with the expected one
+func verifyChecksum(result *DownloadResult, opts *DownloadOptions) error {
+	if opts.Hash == nil {
+		return nil
+	}
+
+	result.Checksum = hex.EncodeToString(opts.Hash.Sum(nil))
+	if opts.ExpectedChecksum != "" && !strings.EqualFold(result.Checksum, opts.ExpectedChecksum) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", opts.ExpectedChecksum, result.Checksum)
+	}
+	return nil
+}
+
+// contentRangeStart returns the first byte position of a Content-Range header, or -1
+func contentRangeStart(resp *http.Response) int64 {
+	// Content-Range: bytes <start>-<end>/<size>
+	value, ok := strings.CutPrefix(resp.Header.Get("Content-Range"), "bytes ")
+	if !ok {
+		return -1
+	}
+
+	start, _, ok := strings.Cut(value, "-")
+	if !ok {
+		return -1
+	}
+
+	n, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// contentRangeSize returns the complete length of a Content-Range header, or -1
+func contentRangeSize(header http.Header) int64 {
+	// Content-Range: bytes <start>-<end>/<size>, or bytes */<size> on a 416 response
+	value, ok := strings.CutPrefix(header.Get("Content-Range"), "bytes ")
+	if !ok {
+		return -1
+	}
+
+	_, size, ok := strings.Cut(value, "/")
+	if !ok {
+		return -1
+	}
+
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
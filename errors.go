@@ -1,44 +1,222 @@
 package gorthanc
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matching the HTTP status of an HTTPError, to be used with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+	ErrServerError  = errors.New("server error")
+)
+
+// Sentinel errors matching the Orthanc error code of an OrthancError or JobError, to be used with errors.Is
+var (
+	ErrInternalError       = &OrthancError{OrthancStatus: -1, OrthancError: "Internal error"}
+	ErrPlugin              = &OrthancError{OrthancStatus: 1, OrthancError: "Error encountered within the plugin engine"}
+	ErrNotImplemented      = &OrthancError{OrthancStatus: 2, OrthancError: "Not implemented yet"}
+	ErrParameterOutOfRange = &OrthancError{OrthancStatus: 3, OrthancError: "Parameter out of range"}
+	ErrBadParameterType    = &OrthancError{OrthancStatus: 5, OrthancError: "Bad type for a parameter"}
+	ErrBadSequenceOfCalls  = &OrthancError{OrthancStatus: 6, OrthancError: "Bad sequence of calls"}
+	ErrInexistentItem      = &OrthancError{OrthancStatus: 7, OrthancError: "Accessing an inexistent item"}
+	ErrBadRequest          = &OrthancError{OrthancStatus: 8, OrthancError: "Bad request"}
+	ErrNetworkProtocol     = &OrthancError{OrthancStatus: 9, OrthancError: "Error in the network protocol"}
+	ErrDatabase            = &OrthancError{OrthancStatus: 11, OrthancError: "Error with the database engine"}
+	ErrUriSyntax           = &OrthancError{OrthancStatus: 12, OrthancError: "Badly formatted URI"}
+	ErrInexistentFile      = &OrthancError{OrthancStatus: 13, OrthancError: "Inexistent file"}
+	ErrCannotWriteFile     = &OrthancError{OrthancStatus: 14, OrthancError: "Cannot write to file"}
+	ErrBadFileFormat       = &OrthancError{OrthancStatus: 15, OrthancError: "Bad file format"}
+	ErrTimeout             = &OrthancError{OrthancStatus: 16, OrthancError: "Timeout"}
+	ErrUnknownResource     = &OrthancError{OrthancStatus: 17, OrthancError: "Unknown resource"}
+	ErrFullStorage         = &OrthancError{OrthancStatus: 19, OrthancError: "The file storage is full"}
+	ErrCorruptedFile       = &OrthancError{OrthancStatus: 20, OrthancError: "Corrupted file (e.g. inconsistent MD5 hash)"}
+	ErrInexistentTag       = &OrthancError{OrthancStatus: 21, OrthancError: "Inexistent tag"}
+	ErrReadOnly            = &OrthancError{OrthancStatus: 22, OrthancError: "Cannot modify a read-only data structure"}
+	ErrUnknownDicomTag     = &OrthancError{OrthancStatus: 27, OrthancError: "Unknown DICOM tag"}
+	ErrBadJson             = &OrthancError{OrthancStatus: 28, OrthancError: "Cannot parse a JSON document"}
+	ErrOrthancUnauthorized = &OrthancError{OrthancStatus: 29, OrthancError: "Bad credentials were provided to an HTTP request"}
+	ErrNotAcceptable       = &OrthancError{OrthancStatus: 34, OrthancError: "Cannot send a response which is acceptable according to the Accept HTTP header"}
+	ErrDatabaseUnavailable = &OrthancError{OrthancStatus: 36, OrthancError: "The database is currently not available"}
+	ErrCanceledJob         = &OrthancError{OrthancStatus: 37, OrthancError: "This job was canceled"}
+	ErrRevision            = &OrthancError{OrthancStatus: 43, OrthancError: "A bad revision number was provided"}
+)
+
+// OrthancError represents the JSON error object returned by Orthanc
+type OrthancError struct {
+	// HTTP method of the failed request
+	Method string `json:"Method"`
+
+	// URI of the failed request
+	Uri string `json:"Uri"`
+
+	// HTTP status, as a text (e.g., "Not Found")
+	HttpError string `json:"HttpError"`
+
+	// HTTP status code
+	HttpStatus int `json:"HttpStatus"`
+
+	// Human-readable message
+	Message string `json:"Message"`
+
+	// Additional details about the error (optional)
+	Details string `json:"Details,omitempty"`
+
+	// Orthanc error, as a text (e.g., "Unknown resource")
+	OrthancError string `json:"OrthancError"`
+
+	// Orthanc error code (e.g., 17 for an unknown resource)
+	OrthancStatus int `json:"OrthancStatus"`
+}
+
+func (e *OrthancError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = e.OrthancError
+	}
+	if e.Details != "" {
+		return fmt.Sprintf("orthanc error %d: %s (%s)", e.OrthancStatus, message, e.Details)
+	}
+	return fmt.Sprintf("orthanc error %d: %s", e.OrthancStatus, message)
+}
+
+// Is reports whether target is an OrthancError with the same error code
+func (e *OrthancError) Is(target error) bool {
+	t, ok := target.(*OrthancError)
+	return ok && t.OrthancStatus == e.OrthancStatus
+}
 
 // HTTPError represents an HTTP error response from the Orthanc server
 type HTTPError struct {
 	StatusCode int
 	Status     string
 	Body       string
+
+	// Headers of the response (e.g., Content-Range of a 416 response)
+	Header http.Header
+
+	// Error object decoded from the body, nil if the body is not an Orthanc error
+	Orthanc *OrthancError
+}
+
+// newHTTPError builds an HTTPError, decoding the Orthanc error object if present
+func newHTTPError(statusCode int, status string, header http.Header, body []byte) *HTTPError {
+	httpErr := &HTTPError{
+		StatusCode: statusCode,
+		Status:     status,
+		Body:       string(body),
+		Header:     header,
+	}
+
+	var orthancErr OrthancError
+	if err := json.Unmarshal(body, &orthancErr); err == nil && (orthancErr.OrthancStatus != 0 || orthancErr.OrthancError != "") {
+		httpErr.Orthanc = &orthancErr
+	}
+
+	return httpErr
 }
 
 func (e *HTTPError) Error() string {
+	if e.Orthanc != nil {
+		return fmt.Sprintf("HTTP %d: %s - %s", e.StatusCode, e.Status, e.Orthanc.Error())
+	}
 	return fmt.Sprintf("HTTP %d: %s - %s", e.StatusCode, e.Status, e.Body)
 }
 
-// IsHTTPError checks if an error is an HTTPError
+// Unwrap exposes the status sentinel error and the decoded Orthanc error
+// so that errors.Is and errors.As see through an HTTPError.
+func (e *HTTPError) Unwrap() []error {
+	var errs []error
+
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		errs = append(errs, ErrNotFound)
+	case e.StatusCode == http.StatusUnauthorized:
+		errs = append(errs, ErrUnauthorized)
+	case e.StatusCode == http.StatusForbidden:
+		errs = append(errs, ErrForbidden)
+	case e.StatusCode == http.StatusConflict:
+		errs = append(errs, ErrConflict)
+	case e.StatusCode >= 500:
+		errs = append(errs, ErrServerError)
+	}
+
+	if e.Orthanc != nil {
+		errs = append(errs, e.Orthanc)
+	}
+
+	return errs
+}
+
+// IsHTTPError checks if an error is, or wraps, an HTTPError
 func IsHTTPError(err error) bool {
-	_, ok := err.(*HTTPError)
-	return ok
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr)
 }
 
 // IsNotFound checks if an error is a 404 Not Found error
 func IsNotFound(err error) bool {
-	if httpErr, ok := err.(*HTTPError); ok {
-		return httpErr.StatusCode == 404
-	}
-	return false
+	return errors.Is(err, ErrNotFound)
 }
 
 // IsUnauthorized checks if an error is a 401 Unauthorized error
 func IsUnauthorized(err error) bool {
-	if httpErr, ok := err.(*HTTPError); ok {
-		return httpErr.StatusCode == 401
-	}
-	return false
+	return errors.Is(err, ErrUnauthorized)
 }
 
 // IsForbidden checks if an error is a 403 Forbidden error
 func IsForbidden(err error) bool {
-	if httpErr, ok := err.(*HTTPError); ok {
-		return httpErr.StatusCode == 403
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsConflict checks if an error is a 409 Conflict error
+// Orthanc reports revision mismatches and some resource conflicts with this status.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsServerError checks if an error is a 5xx server error
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServerError)
+}
+
+// GetOrthancError returns the Orthanc error object carried by an error, if any
+func GetOrthancError(err error) (*OrthancError, bool) {
+	var orthancErr *OrthancError
+	if errors.As(err, &orthancErr) {
+		return orthancErr, true
+	}
+	return nil, false
+}
+
+// JobError represents the failure of an Orthanc job
+type JobError struct {
+	JobID            string
+	ErrorCode        int
+	ErrorDescription string
+	ErrorDetails     string
+}
+
+func (e *JobError) Error() string {
+	if e.ErrorDetails != "" {
+		return fmt.Sprintf("job %s failed with error %d: %s (%s)", e.JobID, e.ErrorCode, e.ErrorDescription, e.ErrorDetails)
 	}
-	return false
-}
\ No newline at end of file
+	return fmt.Sprintf("job %s failed with error %d: %s", e.JobID, e.ErrorCode, e.ErrorDescription)
+}
+
+// Is reports whether target is an OrthancError with the same error code as the job
+func (e *JobError) Is(target error) bool {
+	t, ok := target.(*OrthancError)
+	return ok && t.OrthancStatus == e.ErrorCode
+}
+
+// IsJobError checks if an error is, or wraps, a JobError
+func IsJobError(err error) bool {
+	var jobErr *JobError
+	return errors.As(err, &jobErr)
+}
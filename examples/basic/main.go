@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -8,12 +9,16 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Create a new Orthanc client
 	// Replace with your Orthanc server URL and credentials
 	client, err := gorthanc.NewClient(
 		"http://localhost:8243",
 		gorthanc.WithBasicAuth("orthanc", "orthanc"),
 		// gorthanc.WithTimeout(30*time.Second),
+		// gorthanc.WithRetryPolicy(gorthanc.RetryPolicy{MaxRetries: 3}),
+		// gorthanc.WithMiddleware(gorthanc.LoggingMiddleware(slog.Default())),
 	)
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
@@ -21,7 +26,7 @@ func main() {
 
 	// Get system information
 	fmt.Println("Fetching Orthanc system information...")
-	info, err := client.GetSystem()
+	info, err := client.GetSystem(ctx)
 	if err != nil {
 		// Check for specific error types
 		if gorthanc.IsUnauthorized(err) {
@@ -69,7 +74,7 @@ func main() {
 
 	// Fetch system-wide statistics
 	fmt.Println("Fetching Orthanc system statistics...")
-	stats, err := client.GetSystemStatistics() // Note: In gorthanc, this is usually GetStatistics()
+	stats, err := client.GetSystemStatistics(ctx) // Note: In gorthanc, this is usually GetStatistics()
 	if err != nil {
 		if gorthanc.IsUnauthorized(err) {
 			log.Fatal("Authentication failed - check your credentials")
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/proencaj/gorthanc"
+	"github.com/proencaj/gorthanc/types"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, err := gorthanc.NewClient(
+		"http://localhost:8042",
+		gorthanc.WithBasicAuth("orthanc", "orthanc"),
+	)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Example: GetChanges
+	changes, err := client.GetChanges(ctx, &types.ChangesQueryParams{Limit: 10})
+	if err != nil {
+		log.Fatalf("Failed to get changes: %v", err)
+	}
+	for _, change := range changes.Changes {
+		fmt.Printf("#%d %-16s %-8s %s\n", change.Seq, change.ChangeType, change.ResourceType, change.ID)
+	}
+
+	// Example: ChangesWatcher with a callback, resuming from a checkpoint file
+	watcher := client.NewChangesWatcher(&gorthanc.ChangesWatcherOptions{
+		ChangeTypes: []types.ChangeType{types.ChangeTypeStableStudy},
+		Checkpoint:  &gorthanc.FileCheckpointStore{Path: "changes.checkpoint"},
+	})
+
+	fmt.Println("Watching for stable studies, press Ctrl+C to stop...")
+	err = watcher.Run(ctx, func(ctx context.Context, change types.Change) error {
+		study, err := client.GetStudy(ctx, change.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("New stable study %s (%s)\n", study.ID, study.MainDicomTags.StudyDescription)
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Fatalf("Watcher stopped: %v", err)
+	}
+
+	// The same watcher can deliver changes over a channel instead:
+	//
+	// events, errs := watcher.Watch(ctx)
+	// for change := range events {
+	// 	fmt.Println(change.ID)
+	// }
+	// if err := <-errs; err != nil {
+	// 	log.Fatal(err)
+	// }
+}
@@ -1,22 +1,23 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
-	"mime"
-	"mime/multipart"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/proencaj/gorthanc"
 	"github.com/proencaj/gorthanc/types"
 )
 
 func main() {
+	ctx := context.Background()
+
 	client, err := gorthanc.NewClient(
 		"http://localhost:8243",
 		gorthanc.WithBasicAuth("orthanc", "orthanc"),
@@ -34,7 +35,7 @@ func main() {
 
 	// Example: Search all studies
 	fmt.Println("--- Search All Studies ---")
-	studies, err := client.QidoSearchStudies(nil)
+	studies, err := client.QidoSearchStudies(ctx, nil)
 	if err != nil {
 		log.Fatalf("Failed to search studies: %v", err)
 	}
@@ -48,21 +49,43 @@ func main() {
 			Limit: 5,
 		},
 	}
-	filteredStudies, err := client.QidoSearchStudies(studyParams)
+	filteredStudies, err := client.QidoSearchStudies(ctx, studyParams)
 	if err != nil {
 		log.Fatalf("Failed to search filtered studies: %v", err)
 	}
 	fmt.Printf("Found %d studies (limited to 5)\n", len(filteredStudies))
 
+	// Example: Search studies matching arbitrary attributes
+	fmt.Println("\n--- Search Studies with Attribute Matching ---")
+	matchParams := &types.QidoStudyQueryParams{
+		PatientName: "Doe^J*",
+		QidoQueryParams: types.QidoQueryParams{
+			Limit:        10,
+			Offset:       0,
+			Includefield: []string{"PatientAge", "InstitutionName"},
+			Match: map[string]string{
+				"StudyDate":  types.QidoDateRange(time.Now().AddDate(-1, 0, 0), time.Time{}),
+				"PatientSex": "F",
+			},
+		},
+	}
+	matchedStudies, err := client.QidoSearchStudies(ctx, matchParams)
+	if err != nil {
+		log.Printf("Failed to search studies: %v", err)
+	}
+	for _, study := range matchedStudies {
+		fmt.Printf("%s %s (age %s)\n", study.StudyDate, study.PatientName, study.Dataset.String("00101010"))
+	}
+
 	if len(studies) > 0 {
 		// Get the first study's UID from the response
-		studyUID := getStudyInstanceUID(studies[0])
+		studyUID := studies[0].StudyInstanceUID
 		if studyUID != "" {
 			fmt.Printf("\nUsing Study UID: %s\n", studyUID)
 
 			// Example: Search series in a study
 			fmt.Println("\n--- Search Series in Study ---")
-			series, err := client.QidoSearchSeries(studyUID, nil)
+			series, err := client.QidoSearchSeries(ctx, studyUID, nil)
 			if err != nil {
 				log.Fatalf("Failed to search series: %v", err)
 			}
@@ -71,13 +94,13 @@ func main() {
 
 			// If we have series, search for instances
 			if len(series) > 0 {
-				seriesUID := getSeriesInstanceUID(series[0])
+				seriesUID := series[0].SeriesInstanceUID
 				if seriesUID != "" {
 					fmt.Printf("\nUsing Series UID: %s\n", seriesUID)
 
 					// Example: Search instances in a series
 					fmt.Println("\n--- Search Instances in Series ---")
-					instances, err := client.QidoSearchInstances(studyUID, seriesUID, nil)
+					instances, err := client.QidoSearchInstances(ctx, studyUID, seriesUID, nil)
 					if err != nil {
 						log.Fatalf("Failed to search instances: %v", err)
 					}
@@ -92,7 +115,7 @@ func main() {
 
 					// Example: Retrieve study metadata
 					fmt.Println("--- Retrieve Study Metadata ---")
-					studyMetadata, err := client.WadoRsRetrieveStudyMetadata(studyUID)
+					studyMetadata, err := client.WadoRsRetrieveStudyMetadata(ctx, studyUID)
 					if err != nil {
 						log.Printf("Failed to retrieve study metadata: %v", err)
 					} else {
@@ -102,7 +125,7 @@ func main() {
 
 					// Example: Retrieve series metadata
 					fmt.Println("\n--- Retrieve Series Metadata ---")
-					seriesMetadata, err := client.WadoRsRetrieveSeriesMetadata(studyUID, seriesUID)
+					seriesMetadata, err := client.WadoRsRetrieveSeriesMetadata(ctx, studyUID, seriesUID)
 					if err != nil {
 						log.Printf("Failed to retrieve series metadata: %v", err)
 					} else {
@@ -111,18 +134,22 @@ func main() {
 
 					// Example: Retrieve rendered instance (if we have instances)
 					if len(instances) > 0 {
-						instanceUID := getSOPInstanceUID(instances[0])
+						instanceUID := instances[0].SOPInstanceUID
 						if instanceUID != "" {
 							fmt.Printf("\nUsing Instance UID: %s\n", instanceUID)
 
 							// Example: Retrieve instance metadata
 							fmt.Println("\n--- Retrieve Instance Metadata ---")
-							instanceMetadata, err := client.WadoRsRetrieveInstanceMetadata(studyUID, seriesUID, instanceUID)
+							instanceMetadata, err := client.WadoRsRetrieveInstanceMetadata(ctx, studyUID, seriesUID, instanceUID)
 							if err != nil {
 								log.Printf("Failed to retrieve instance metadata: %v", err)
-							} else {
-								fmt.Printf("Retrieved metadata for instance\n")
-								printJSON(instanceMetadata)
+							} else if len(instanceMetadata) > 0 {
+								metadata := instanceMetadata[0]
+								fmt.Printf("Patient: %s\n", metadata.PersonName(types.TagPatientName))
+								fmt.Printf("Size: %dx%d\n", metadata.Int(types.TagRows), metadata.Int(types.TagColumns))
+								if uri := metadata.BulkDataURI("7FE00010"); uri != "" {
+									fmt.Printf("Pixel data available at: %s\n", uri)
+								}
 							}
 
 							// Example: Retrieve rendered instance as JPEG
@@ -130,7 +157,7 @@ func main() {
 							renderedParams := &types.WadoRsRenderedParams{
 								Quality: 90,
 							}
-							resp, err := client.WadoRsRetrieveRenderedInstance(studyUID, seriesUID, instanceUID, renderedParams)
+							resp, err := client.WadoRsRetrieveRenderedInstance(ctx, studyUID, seriesUID, instanceUID, renderedParams)
 							if err != nil {
 								log.Printf("Failed to retrieve rendered instance: %v", err)
 							} else {
@@ -150,70 +177,66 @@ func main() {
 								}
 							}
 
-							// Example: Retrieve single DICOM instance
+							// Example: Retrieve single DICOM instance, reading the multipart response part by part
 							fmt.Println("\n--- Retrieve Single DICOM Instance ---")
-							dicomResp, err := client.WadoRsRetrieveInstance(studyUID, seriesUID, instanceUID)
+							dicomResp, err := client.WadoRsRetrieveInstance(ctx, studyUID, seriesUID, instanceUID, nil)
 							if err != nil {
 								log.Printf("Failed to retrieve DICOM instance: %v", err)
 							} else {
-								defer dicomResp.Body.Close()
-
-								// Save DICOM instance to tmp folder
-								instanceDir := "./tmp/instance"
-								if err := os.MkdirAll(instanceDir, 0755); err != nil {
-									log.Printf("Failed to create directory: %v", err)
+								reader, err := gorthanc.NewMultipartReader(dicomResp)
+								if err != nil {
+									dicomResp.Body.Close()
+									log.Printf("Failed to read DICOM instance: %v", err)
 								} else {
-									count, err := saveMultipartDicomFiles(dicomResp.Body, dicomResp.Header.Get("Content-Type"), instanceDir)
-									if err != nil {
-										log.Printf("Failed to save DICOM instance: %v", err)
-									} else {
-										fmt.Printf("Saved %d DICOM file(s) to: %s\n", count, instanceDir)
+									for part, err := range reader.Parts() {
+										if err != nil {
+											log.Printf("Failed to read part: %v", err)
+											break
+										}
+										data, err := io.ReadAll(part.Body)
+										if err != nil {
+											log.Printf("Failed to read part: %v", err)
+											break
+										}
+										fmt.Printf("Part %s (%s): %d bytes\n", part.ContentLocation, part.ContentType, len(data))
 									}
+									reader.Close()
+								}
+							}
+
+							// Example: Retrieve frames of the instance
+							fmt.Println("\n--- Retrieve Frames ---")
+							frames, err := client.WadoRsGetFrames(ctx, studyUID, seriesUID, instanceUID, "1", &types.WadoRsRetrieveParams{
+								TransferSyntax: "1.2.840.10008.1.2.1",
+							})
+							if err != nil {
+								log.Printf("Failed to retrieve frames: %v", err)
+							} else {
+								for i, frame := range frames {
+									fmt.Printf("Frame %d: %d bytes\n", i+1, len(frame))
 								}
 							}
 
 							// Example: Retrieve entire series as DICOM files
 							fmt.Println("\n--- Retrieve Entire Series ---")
-							seriesResp, err := client.WadoRsRetrieveSeries(studyUID, seriesUID)
+							seriesDir := fmt.Sprintf("./tmp/series/%s", sanitizeUID(seriesUID))
+							count, err := client.WadoRsSaveSeries(ctx, studyUID, seriesUID, seriesDir, nil)
 							if err != nil {
-								log.Printf("Failed to retrieve series: %v", err)
+								log.Printf("Failed to save series DICOM files: %v", err)
 							} else {
-								defer seriesResp.Body.Close()
-
-								// Save all DICOM files from series to tmp folder
-								seriesDir := fmt.Sprintf("./tmp/series/%s", sanitizeUID(seriesUID))
-								if err := os.MkdirAll(seriesDir, 0755); err != nil {
-									log.Printf("Failed to create directory: %v", err)
-								} else {
-									count, err := saveMultipartDicomFiles(seriesResp.Body, seriesResp.Header.Get("Content-Type"), seriesDir)
-									if err != nil {
-										log.Printf("Failed to save series DICOM files: %v", err)
-									} else {
-										fmt.Printf("Saved %d DICOM file(s) to: %s\n", count, seriesDir)
-									}
-								}
+								fmt.Printf("Saved %d DICOM file(s) to: %s\n", count, seriesDir)
 							}
 
-							// Example: Retrieve entire study as DICOM files
+							// Example: Retrieve entire study as DICOM files, in the transfer syntax stored by Orthanc
 							fmt.Println("\n--- Retrieve Entire Study ---")
-							studyResp, err := client.WadoRsRetrieveStudy(studyUID)
+							studyDir := fmt.Sprintf("./tmp/study/%s", sanitizeUID(studyUID))
+							count, err = client.WadoRsSaveStudy(ctx, studyUID, studyDir, &types.WadoRsRetrieveParams{
+								TransferSyntax: "*",
+							})
 							if err != nil {
-								log.Printf("Failed to retrieve study: %v", err)
+								log.Printf("Failed to save study DICOM files: %v", err)
 							} else {
-								defer studyResp.Body.Close()
-
-								// Save all DICOM files from study to tmp folder
-								studyDir := fmt.Sprintf("./tmp/study/%s", sanitizeUID(studyUID))
-								if err := os.MkdirAll(studyDir, 0755); err != nil {
-									log.Printf("Failed to create directory: %v", err)
-								} else {
-									count, err := saveMultipartDicomFiles(studyResp.Body, studyResp.Header.Get("Content-Type"), studyDir)
-									if err != nil {
-										log.Printf("Failed to save study DICOM files: %v", err)
-									} else {
-										fmt.Printf("Saved %d DICOM file(s) to: %s\n", count, studyDir)
-									}
-								}
+								fmt.Printf("Saved %d DICOM file(s) to: %s\n", count, studyDir)
 							}
 						}
 					}
@@ -223,113 +246,34 @@ func main() {
 
 		fmt.Println("\nDICOMweb examples completed.")
 	}
-}
-
-// saveMultipartDicomFiles parses a multipart response and saves each DICOM part as a file
-func saveMultipartDicomFiles(body io.Reader, contentType string, outputDir string) (int, error) {
-	// Parse the Content-Type header to get the boundary
-	mediaType, params, err := mime.ParseMediaType(contentType)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse content type: %w", err)
-	}
-
-	if !strings.HasPrefix(mediaType, "multipart/") {
-		// Not multipart, save as single file
-		data, err := io.ReadAll(body)
-		if err != nil {
-			return 0, fmt.Errorf("failed to read body: %w", err)
-		}
-		filePath := filepath.Join(outputDir, "instance_0.dcm")
-		if err := os.WriteFile(filePath, data, 0644); err != nil {
-			return 0, fmt.Errorf("failed to write file: %w", err)
-		}
-		return 1, nil
-	}
-
-	boundary := params["boundary"]
-	if boundary == "" {
-		return 0, fmt.Errorf("no boundary found in content type")
-	}
-
-	// Read entire body into buffer for multipart parsing
-	bodyData, err := io.ReadAll(body)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read body: %w", err)
-	}
 
-	reader := multipart.NewReader(bytes.NewReader(bodyData), boundary)
-	count := 0
+	// =========================================================================
+	// STOW-RS Example (Store)
+	// =========================================================================
 
-	for {
-		part, err := reader.NextPart()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			// Try alternative parsing if standard multipart fails
-			return parseManualMultipart(bodyData, boundary, outputDir)
+	if len(os.Args) > 1 {
+		fmt.Println("\n=== STOW-RS: Store Instances ===")
+		var files []io.Reader
+		for _, name := range os.Args[1:] {
+			file, err := os.Open(name)
+			if err != nil {
+				log.Fatalf("Failed to open %s: %v", name, err)
+			}
+			defer file.Close()
+			files = append(files, file)
 		}
 
-		data, err := io.ReadAll(part)
+		stowResult, err := client.StowRsStore(ctx, "", files...)
 		if err != nil {
-			return count, fmt.Errorf("failed to read part: %w", err)
+			log.Fatalf("Failed to store instances: %v", err)
 		}
-
-		filePath := filepath.Join(outputDir, fmt.Sprintf("instance_%d.dcm", count))
-		if err := os.WriteFile(filePath, data, 0644); err != nil {
-			return count, fmt.Errorf("failed to write file: %w", err)
+		for _, stored := range stowResult.Stored {
+			fmt.Printf("Stored %s\n", stored.SOPInstanceUID)
 		}
-
-		count++
-		part.Close()
-	}
-
-	return count, nil
-}
-
-// parseManualMultipart handles multipart parsing manually when standard parser fails
-func parseManualMultipart(data []byte, boundary string, outputDir string) (int, error) {
-	delimiter := []byte("--" + boundary)
-	parts := bytes.Split(data, delimiter)
-	count := 0
-
-	for _, part := range parts {
-		// Skip empty parts and closing boundary
-		if len(part) == 0 || bytes.Equal(bytes.TrimSpace(part), []byte("--")) {
-			continue
-		}
-
-		// Find the end of headers (double CRLF or double LF)
-		headerEnd := bytes.Index(part, []byte("\r\n\r\n"))
-		if headerEnd == -1 {
-			headerEnd = bytes.Index(part, []byte("\n\n"))
-			if headerEnd == -1 {
-				continue
-			}
-			headerEnd += 2
-		} else {
-			headerEnd += 4
-		}
-
-		// Extract body (skip headers)
-		body := part[headerEnd:]
-		// Trim trailing CRLF
-		body = bytes.TrimSuffix(body, []byte("\r\n"))
-		body = bytes.TrimSuffix(body, []byte("\n"))
-
-		if len(body) == 0 {
-			continue
-		}
-
-		filePath := filepath.Join(outputDir, fmt.Sprintf("instance_%d.dcm", count))
-		if err := os.WriteFile(filePath, body, 0644); err != nil {
-			return count, fmt.Errorf("failed to write file: %w", err)
+		for _, failed := range stowResult.Failed {
+			fmt.Printf("Failed %s (reason 0x%04X)\n", failed.SOPInstanceUID, failed.FailureReason)
 		}
-
-		count++
 	}
-
-	return count, nil
 }
 
 func sanitizeUID(uid string) string {
@@ -350,48 +294,3 @@ func printJSON(data interface{}) {
 	}
 	fmt.Println(output)
 }
-
-// Helper function to extract Study Instance UID from QIDO response
-func getStudyInstanceUID(study map[string]interface{}) string {
-	// Study Instance UID is at tag 0020000D
-	if val, ok := study["0020000D"]; ok {
-		if tagVal, ok := val.(map[string]interface{}); ok {
-			if value, ok := tagVal["Value"].([]interface{}); ok && len(value) > 0 {
-				if str, ok := value[0].(string); ok {
-					return str
-				}
-			}
-		}
-	}
-	return ""
-}
-
-// Helper function to extract Series Instance UID from QIDO response
-func getSeriesInstanceUID(series map[string]interface{}) string {
-	// Series Instance UID is at tag 0020000E
-	if val, ok := series["0020000E"]; ok {
-		if tagVal, ok := val.(map[string]interface{}); ok {
-			if value, ok := tagVal["Value"].([]interface{}); ok && len(value) > 0 {
-				if str, ok := value[0].(string); ok {
-					return str
-				}
-			}
-		}
-	}
-	return ""
-}
-
-// Helper function to extract SOP Instance UID from QIDO response
-func getSOPInstanceUID(instance map[string]interface{}) string {
-	// SOP Instance UID is at tag 00080018
-	if val, ok := instance["00080018"]; ok {
-		if tagVal, ok := val.(map[string]interface{}); ok {
-			if value, ok := tagVal["Value"].([]interface{}); ok && len(value) > 0 {
-				if str, ok := value[0].(string); ok {
-					return str
-				}
-			}
-		}
-	}
-	return ""
-}
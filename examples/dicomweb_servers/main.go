@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,6 +11,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	client, err := gorthanc.NewClient(
 		"http://localhost:8243",
 		gorthanc.WithBasicAuth("orthanc", "orthanc"),
@@ -19,7 +22,7 @@ func main() {
 	}
 
 	// Example: GetDicomWebServers
-	servers, err := client.GetDicomWebServers()
+	servers, err := client.GetDicomWebServers(ctx)
 	if err != nil {
 		log.Fatalf("Failed to get DICOMweb servers: %v", err)
 	}
@@ -27,7 +30,7 @@ func main() {
 	fmt.Println(servers)
 
 	// Example: GetDicomWebServersExpanded
-	expandedServers, err := client.GetDicomWebServersExpanded()
+	expandedServers, err := client.GetDicomWebServersExpanded(ctx)
 	if err != nil {
 		log.Fatalf("Failed to get expanded DICOMweb servers: %v", err)
 	}
@@ -48,7 +51,7 @@ func main() {
 		ChunkedTransfers: gorthanc.BoolPtr(true),
 		HasWadoRsUniversalTransferSyntax: gorthanc.BoolPtr(true),
 	}
-	err = client.CreateOrUpdateDicomWebServer("sample", createRequest)
+	err = client.CreateOrUpdateDicomWebServer(ctx, "sample", createRequest)
 	if err != nil {
 		log.Fatalf("Failed to create DICOMweb server: %v", err)
 	}
@@ -58,7 +61,7 @@ func main() {
 	minimalRequest := &types.DicomWebServerCreateRequest{
 		Url: "http://another-server:8042/dicom-web",
 	}
-	err = client.CreateOrUpdateDicomWebServer("minimal-server", minimalRequest)
+	err = client.CreateOrUpdateDicomWebServer(ctx, "minimal-server", minimalRequest)
 	if err != nil {
 		log.Fatalf("Failed to create minimal DICOMweb server: %v", err)
 	}
@@ -72,7 +75,7 @@ func main() {
 		ChunkedTransfers: gorthanc.BoolPtr(false), // Orthanc <= 1.5.6
 		HasWadoRsUniversalTransferSyntax: gorthanc.BoolPtr(false), // DICOMweb plugin <= 1.0
 	}
-	err = client.CreateOrUpdateDicomWebServer("legacy-server", legacyRequest)
+	err = client.CreateOrUpdateDicomWebServer(ctx, "legacy-server", legacyRequest)
 	if err != nil {
 		log.Fatalf("Failed to create legacy DICOMweb server: %v", err)
 	}
@@ -86,34 +89,77 @@ func main() {
 		Password: "new-password",
 		HasDelete: gorthanc.BoolPtr(false),
 	}
-	err = client.CreateOrUpdateDicomWebServer("sample", updateRequest)
+	err = client.CreateOrUpdateDicomWebServer(ctx, "sample", updateRequest)
 	if err != nil {
 		log.Fatalf("Failed to update DICOMweb server: %v", err)
 	}
 	fmt.Println("\nDICOMweb server 'sample' updated successfully")
 
 	// Example: GetDicomWebServers (after creating new servers)
-	updatedServers, err := client.GetDicomWebServers()
+	updatedServers, err := client.GetDicomWebServers(ctx)
 	if err != nil {
 		log.Fatalf("Failed to get updated DICOMweb servers: %v", err)
 	}
 	fmt.Println("\nUpdated DICOMweb Servers list:")
 	fmt.Println(updatedServers)
 
+	// Example: QueryDicomWebServer, running a QIDO-RS query on the remote server through Orthanc
+	remoteStudies, err := client.QueryDicomWebServer(ctx, "sample", &types.DicomWebServerGetRequest{
+		Uri: "/studies",
+		Arguments: map[string]string{
+			"limit": "10",
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to query DICOMweb server: %v", err)
+	}
+	fmt.Printf("\nFound %d studies on 'sample'\n", len(remoteStudies))
+
+	// Example: RetrieveFromDicomWebServerAsync, pulling the first remote study into Orthanc
+	if len(remoteStudies) > 0 {
+		job, err := client.RetrieveFromDicomWebServerAsync(ctx, "sample", &types.DicomWebServerRetrieveRequest{
+			Resources: []types.DicomWebServerResource{
+				{Study: remoteStudies[0].String(types.TagStudyInstanceUID)},
+			},
+		})
+		if err != nil {
+			log.Fatalf("Failed to retrieve study: %v", err)
+		}
+		if _, err := job.Wait(ctx, nil); err != nil {
+			log.Fatalf("Failed to retrieve study: %v", err)
+		}
+		fmt.Println("Study retrieved from 'sample'")
+	}
+
+	// Example: StoreToDicomWebServer, pushing local studies to the remote server
+	localStudies, err := client.GetStudies(ctx, &types.StudiesQueryParams{Limit: 1})
+	if err != nil {
+		log.Fatalf("Failed to get studies: %v", err)
+	}
+	if len(localStudies) > 0 {
+		err = client.StoreToDicomWebServer(ctx, "sample", &types.DicomWebServerStowRequest{
+			Resources: localStudies,
+		})
+		if err != nil {
+			log.Fatalf("Failed to store study to DICOMweb server: %v", err)
+		}
+		fmt.Println("Study sent to 'sample'")
+	}
+
 	// Example: DeleteDicomWebServer
-	err = client.DeleteDicomWebServer("minimal-server")
+	err = client.DeleteDicomWebServer(ctx, "minimal-server")
 	if err != nil {
 		log.Fatalf("Failed to delete DICOMweb server: %v", err)
 	}
 	fmt.Println("\nDICOMweb server 'minimal-server' deleted successfully")
 
-	err = client.DeleteDicomWebServer("legacy-server")
+	err = client.DeleteDicomWebServer(ctx, "legacy-server")
 	if err != nil {
 		log.Fatalf("Failed to delete DICOMweb server: %v", err)
 	}
 	fmt.Println("DICOMweb server 'legacy-server' deleted successfully")
 
-	err = client.DeleteDicomWebServer("sample")
+	err = client.DeleteDicomWebServer(ctx, "sample")
 	if err != nil {
 		log.Fatalf("Failed to delete DICOMweb server: %v", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"bufio"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Create a new Orthanc client
 	// Replace with your Orthanc server URL and credentials
 	client, err := gorthanc.NewClient(
@@ -29,7 +32,7 @@ func main() {
 	params := &types.InstancesQueryParams{
 		Limit: 100,
 	}
-	instances, err := client.GetAllInstances(params)
+	instances, err := client.GetAllInstances(ctx, params)
 	if err != nil {
 		log.Fatalf("Failed to get instances: %v", err)
 	}
@@ -41,7 +44,7 @@ func main() {
 
 	fmt.Println("Logging information about the first 5 instances")
 	for _, instanceId := range instances[:min(5, len(instances))] {
-		instanceDetails, err := client.GetInstanceDetails(instanceId)
+		instanceDetails, err := client.GetInstanceDetails(ctx, instanceId)
 		if err != nil { 
 			log.Fatalf("Failed to get instance details: %v ", err)
 		}
@@ -63,7 +66,7 @@ func main() {
 	}
 	defer output.Close()
 
-	resp, err := client.DownloadDicomFile(instances[1])
+	resp, err := client.DownloadDicomFile(ctx, instances[1])
 	if err != nil {
 		log.Fatalf("Failed to download DICOM file: %v", err)
 	}
@@ -89,7 +92,7 @@ func main() {
 	// Example: DeleteInstance (Commented to not delete nothing)
 
 	if len(instances) > 1 {
-		err := client.DeleteInstance(instances[1])
+		err := client.DeleteInstance(ctx, instances[1])
 		if err != nil {
 			log.Fatalf("Failed to delete instance: %v", err)
 		}
@@ -109,7 +112,7 @@ func main() {
 
 	reader := bufio.NewReaderSize(file, 256*1024)
 
-	result, err := client.UploadDicomFile(reader)
+	result, err := client.UploadDicomFile(ctx, reader)
 
 	if err != nil {
 		log.Fatalf("Failed to upload the dicom file: %v", err)
@@ -135,7 +138,7 @@ func main() {
 			KeepSource: gorthanc.BoolPtr(true),
 		}
 
-		resp, err := client.AnonymizeInstance(insId, anonymizeRequest)
+		resp, err := client.AnonymizeInstance(ctx, insId, anonymizeRequest)
 		if err != nil {
 			log.Fatalf("Failed to download anonymized DICOM file: %v", err)
 		}
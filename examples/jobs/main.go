@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/proencaj/gorthanc"
+	"github.com/proencaj/gorthanc/types"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client, err := gorthanc.NewClient(
+		"http://localhost:8042",
+		gorthanc.WithBasicAuth("orthanc", "orthanc"),
+	)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Example: GetJobs
+	jobs, err := client.GetJobs(ctx)
+	if err != nil {
+		log.Fatalf("Failed to get jobs: %v", err)
+	}
+	fmt.Println(jobs)
+
+	// Example: GetJobsExpanded
+	expandedJobs, err := client.GetJobsExpanded(ctx)
+	if err != nil {
+		log.Fatalf("Failed to get expanded jobs: %v", err)
+	}
+	for _, job := range expandedJobs {
+		fmt.Printf("%s  %-25s %-8s %3d%%\n", job.ID, job.Type, job.State, job.Progress)
+	}
+
+	// Example: GetJob
+	if len(jobs) > 0 {
+		job, err := client.GetJob(ctx, jobs[0])
+		if err != nil {
+			log.Fatalf("Failed to get job: %v", err)
+		}
+
+		jsonData, _ := json.MarshalIndent(job, "", "  ")
+		fmt.Println(string(jsonData))
+
+		// Example: WaitForJob
+		job, err = client.WaitForJob(ctx, job.ID, nil)
+		if err != nil {
+			if gorthanc.IsJobError(err) {
+				log.Fatalf("Job failed: %v", err)
+			}
+			log.Fatalf("Failed to wait for job: %v", err)
+		}
+		fmt.Printf("Job %s finished with state %s\n", job.ID, job.State)
+	}
+
+	// Example: CreateArchiveAsync, one ZIP containing several studies
+	studies, err := client.GetStudies(ctx, &types.StudiesQueryParams{Limit: 3})
+	if err != nil {
+		log.Fatalf("Failed to get studies: %v", err)
+	}
+	if len(studies) > 0 {
+		archiveJob, err := client.CreateArchiveAsync(ctx, studies, &types.CreateArchiveOptions{Priority: 10})
+		if err != nil {
+			log.Fatalf("Failed to submit archive job: %v", err)
+		}
+		if _, err := archiveJob.Wait(ctx, nil); err != nil {
+			log.Fatalf("Archive job failed: %v", err)
+		}
+
+		output, err := os.Create("studies.zip")
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer output.Close()
+
+		result, err := archiveJob.SaveArchive(ctx, output, nil)
+		if err != nil {
+			log.Fatalf("Failed to download archive: %v", err)
+		}
+		fmt.Printf("Archive of %d studies saved to studies.zip (%d bytes)\n", len(studies), result.Written)
+	}
+
+	// Example: PauseJob / ResumeJob / CancelJob / ResubmitJob
+	// err = client.PauseJob(ctx, jobID)
+	// err = client.ResumeJob(ctx, jobID)
+	// err = client.CancelJob(ctx, jobID)
+	// err = client.ResubmitJob(ctx, jobID)
+}
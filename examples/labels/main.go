@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/proencaj/gorthanc"
+	"github.com/proencaj/gorthanc/types"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client, err := gorthanc.NewClient(
+		"http://localhost:8042",
+		gorthanc.WithBasicAuth("orthanc", "orthanc"),
+	)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	studies, err := client.GetStudies(ctx, &types.StudiesQueryParams{Limit: 1})
+	if err != nil {
+		log.Fatalf("Failed to get studies: %v", err)
+	}
+	if len(studies) == 0 {
+		log.Fatal("No study found")
+	}
+	studyID := studies[0]
+
+	// Example: AddLabel
+	if err := client.AddLabel(ctx, types.ResourceLevelStudy, studyID, "to-route"); err != nil {
+		log.Fatalf("Failed to add label: %v", err)
+	}
+
+	// Example: GetLabels
+	labels, err := client.GetLabels(ctx, types.ResourceLevelStudy, studyID)
+	if err != nil {
+		log.Fatalf("Failed to get labels: %v", err)
+	}
+	fmt.Printf("Labels of study %s: %v\n", studyID, labels)
+
+	// Example: GetAllLabels
+	allLabels, err := client.GetAllLabels(ctx)
+	if err != nil {
+		log.Fatalf("Failed to get all labels: %v", err)
+	}
+	fmt.Printf("Labels in use: %v\n", allLabels)
+
+	// Example: find the labeled studies
+	found, err := client.FindExpanded(ctx, &types.ToolsFindRequest{
+		Level:            types.ResourceLevelStudy,
+		Query:            map[string]string{},
+		Labels:           []string{"to-route"},
+		LabelsConstraint: types.LabelsConstraintAll,
+	})
+	if err != nil {
+		log.Fatalf("Failed to find labeled studies: %v", err)
+	}
+	fmt.Printf("Found %d studies labeled to-route\n", len(found))
+
+	// Example: RemoveLabel
+	if err := client.RemoveLabel(ctx, types.ResourceLevelStudy, studyID, "to-route"); err != nil {
+		log.Fatalf("Failed to remove label: %v", err)
+	}
+}
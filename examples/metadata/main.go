@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/proencaj/gorthanc"
+	"github.com/proencaj/gorthanc/types"
+)
+
+func main() {
+	ctx := context.Background()
+
+	client, err := gorthanc.NewClient(
+		"http://localhost:8042",
+		gorthanc.WithBasicAuth("orthanc", "orthanc"),
+	)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	studies, err := client.GetStudies(ctx, &types.StudiesQueryParams{Limit: 1})
+	if err != nil {
+		log.Fatalf("Failed to get studies: %v", err)
+	}
+	if len(studies) == 0 {
+		log.Fatal("No study found")
+	}
+	studyID := studies[0]
+
+	// Example: GetMetadataExpanded
+	metadata, err := client.GetMetadataExpanded(ctx, types.ResourceLevelStudy, studyID)
+	if err != nil {
+		log.Fatalf("Failed to get metadata: %v", err)
+	}
+	for name, value := range metadata {
+		fmt.Printf("%-30s %s\n", name, value)
+	}
+
+	// Example: SetMetadata with optimistic concurrency
+	// User-defined metadata must be declared in the UserMetadata option of the Orthanc configuration.
+	const name = "WorkflowState"
+
+	revision := ""
+	current, err := client.GetMetadata(ctx, types.ResourceLevelStudy, studyID, name)
+	if err != nil && !gorthanc.IsNotFound(err) {
+		log.Fatalf("Failed to get metadata: %v", err)
+	}
+	if current != nil {
+		fmt.Printf("Current state: %s (revision %s)\n", current.Value, current.Revision)
+		revision = current.Revision
+	}
+
+	err = client.SetMetadata(ctx, types.ResourceLevelStudy, studyID, name, "routed", revision)
+	if gorthanc.IsConflict(err) {
+		log.Fatal("Another worker updated the workflow state in the meantime")
+	}
+	if err != nil {
+		log.Fatalf("Failed to set metadata: %v", err)
+	}
+
+	// Example: GetAttachments
+	attachments, err := client.GetAttachments(ctx, types.ResourceLevelStudy, studyID)
+	if err != nil {
+		log.Fatalf("Failed to get attachments: %v", err)
+	}
+	fmt.Printf("Attachments: %v\n", attachments)
+}
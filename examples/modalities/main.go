@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/proencaj/gorthanc"
 	"github.com/proencaj/gorthanc/types"
 )
 
 func main() {
+	ctx := context.Background()
+
 	client, err := gorthanc.NewClient(
 		"http://localhost:8243",
 		gorthanc.WithBasicAuth("orthanc", "orthanc"),
@@ -19,7 +23,7 @@ func main() {
 	}
 
 	// Example: GetModalities
-	modalities, err := client.GetModalities()
+	modalities, err := client.GetModalities(ctx)
 	if err != nil {
 		log.Fatalf("Failed to get modalities: %v", err)
 	}
@@ -27,7 +31,7 @@ func main() {
 
 	// Example: GetModalityDetails
 	if len(modalities) > 0 {
-		modality, err := client.GetModalityDetails(modalities[0])
+		modality, err := client.GetModalityDetails(ctx, modalities[0])
 		if err != nil {
 			log.Fatalf("Failed to get modality details: %v", err)
 		}
@@ -38,19 +42,41 @@ func main() {
 
 	// Example: EchoModality
 	if len(modalities) > 0 {
-		err = client.EchoModality(modalities[0])
+		echo, err := client.EchoModality(ctx, modalities[0], &types.ModalityEchoRequest{
+			Timeout:   5,
+			CheckFind: gorthanc.BoolPtr(true),
+		})
 		if err != nil {
-			fmt.Printf("Modality not reachable: %v\n", err)
+			log.Fatalf("Failed to echo modality: %v", err)
+		}
+		if echo.Success {
+			fmt.Printf("Modality reachable in %s\n", echo.Latency)
+		} else {
+			fmt.Printf("Modality not reachable: %s\n", echo.ErrorMessage)
 		}
 	}
 
+	// Example: DicomEcho, testing a PACS before registering it
+	echo, err := client.DicomEcho(ctx, &types.DicomEchoRequest{
+		AET:     "TEST_MODALITY",
+		Host:    "localhost",
+		Port:    4242,
+		Timeout: 5,
+	})
+	if err != nil {
+		log.Fatalf("Failed to echo modality: %v", err)
+	}
+	if !echo.Success {
+		fmt.Printf("TEST_MODALITY not reachable: %s\n", echo.ErrorMessage)
+	}
+
 	// Example: CreateOrUpdateModality
 	request := &types.ModalityCreateRequest{
 		AET:  "TEST_MODALITY",
 		Host: "localhost",
 		Port: 4242,
 	}
-	err = client.CreateOrUpdateModality("TEST_MODALITY", request)
+	err = client.CreateOrUpdateModality(ctx, "TEST_MODALITY", request)
 	if err != nil {
 		log.Fatalf("Failed to create modality: %v", err)
 	}
@@ -63,13 +89,72 @@ func main() {
 			"PatientName": "*",
 		},
 	}
-	findResults, err := client.FindInModality("PACS", findRequest)
+	findResults, err := client.FindInModality(ctx, "PACS", findRequest)
 	if err != nil {
 		log.Fatalf("Failed to query modality: %v", err)
 	}
 	jsonData, _ := json.MarshalIndent(findResults, "", "  ")
 	fmt.Println(string(jsonData))
 
+	// Example: QueryModality, keeping the query to browse and retrieve its answers
+	query, err := client.QueryModality(ctx, "PACS", &types.ModalityFindRequest{
+		Level: "Patient",
+		Query: map[string]string{
+			"PatientID": "*",
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to query modality: %v", err)
+	}
+	defer query.Delete(ctx)
+
+	patients, err := query.AnswersExpanded(ctx, &types.QueryAnswersOptions{Simplify: true})
+	if err != nil {
+		log.Fatalf("Failed to get query answers: %v", err)
+	}
+	fmt.Printf("Query %s found %d patients\n", query.ID, len(patients))
+
+	if len(patients) > 0 {
+		// Browse the studies of the first patient
+		studiesQuery, err := query.QueryChildren(ctx, 0, types.ResourceLevelStudy, nil)
+		if err != nil {
+			log.Fatalf("Failed to query studies: %v", err)
+		}
+		studies, err := studiesQuery.AnswersExpanded(ctx, &types.QueryAnswersOptions{Simplify: true})
+		if err != nil {
+			log.Fatalf("Failed to get study answers: %v", err)
+		}
+		fmt.Printf("Patient %v has %d studies\n", patients[0]["PatientID"], len(studies))
+
+		// Retrieve the first study into Orthanc as a job
+		if len(studies) > 0 {
+			job, err := studiesQuery.RetrieveAnswerAsync(ctx, 0, &types.QueryRetrieveRequest{TargetAet: "ORTHANC"})
+			if err != nil {
+				log.Fatalf("Failed to retrieve study: %v", err)
+			}
+			if _, err := job.Wait(ctx, nil); err != nil {
+				log.Fatalf("Failed to retrieve study: %v", err)
+			}
+			fmt.Println("Study retrieved")
+		}
+	}
+
+	// Example: FindWorklist, listing the CT procedures scheduled for today
+	today := time.Now()
+	worklist, err := client.FindWorklist(ctx, "RIS", &types.WorklistFindRequest{
+		Modality:                        "CT",
+		ScheduledProcedureStepStartDate: types.QidoDateRange(today, today),
+	})
+	if err != nil {
+		log.Fatalf("Failed to query worklist: %v", err)
+	}
+	for _, item := range worklist {
+		for _, step := range item.ScheduledProcedureSteps {
+			fmt.Printf("%s %s: %s at %s on %s\n", item.AccessionNumber, item.PatientName,
+				step.ScheduledProcedureStepDescription, step.ScheduledProcedureStepStartTime, step.ScheduledStationAETitle)
+		}
+	}
+
 	// Example: MoveFromModality
 	moveRequest := &types.ModalityMoveRequest{
 		Level:        "Study",
@@ -81,7 +166,7 @@ func main() {
 			{"StudyInstanceUID": "1.2.840.113619.2.55.3.123456789"},
 		},
 	}
-	moveResults, err := client.MoveFromModality("PACS", moveRequest)
+	moveResults, err := client.MoveFromModality(ctx, "PACS", moveRequest)
 	if err != nil {
 		log.Fatalf("Failed to move study: %v", err)
 	}
@@ -98,19 +183,39 @@ func main() {
 			{"StudyInstanceUID": "1.2.840.113619.2.55.3.123456789"},
 		},
 	}
-	err = client.GetFromModality("PACS", getRequest)
+	err = client.GetFromModality(ctx, "PACS", getRequest)
 	if err != nil {
 		log.Fatalf("Failed to get study: %v", err)
 	}
 
 	// Example: StoreToModality
-	err = client.StoreToModality("PACS", "study-orthanc-id")
+	err = client.StoreToModality(ctx, "PACS", "study-orthanc-id")
 	if err != nil {
 		log.Fatalf("Failed to store study: %v", err)
 	}
 
+	// Example: StoreToModalityWithCommitment, deleting the local copy once the PACS committed it
+	commitCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	report, err := client.StoreToModalityWithCommitment(commitCtx, "PACS", &types.ModalityStoreRequest{
+		Resources: []string{"study-orthanc-id"},
+	}, nil)
+	if err != nil {
+		log.Fatalf("Failed to store study with commitment: %v", err)
+	}
+	fmt.Printf("Storage commitment %s: %d committed, %d failed\n", report.Status, len(report.Success), len(report.Failures))
+	for _, failure := range report.Failures {
+		fmt.Printf("Failed to commit %s (reason 0x%04X)\n", failure.SOPInstanceUID, failure.FailureReason)
+	}
+	if report.Status == types.StorageCommitmentStatusSuccess {
+		if err := client.RemoveCommittedInstances(ctx, report.TransactionUID); err != nil {
+			log.Fatalf("Failed to remove committed instances: %v", err)
+		}
+	}
+
 	// Example: DeleteModality
-	err = client.DeleteModality("TEST_MODALITY")
+	err = client.DeleteModality(ctx, "TEST_MODALITY")
 	if err != nil {
 		log.Fatalf("Failed to delete modality: %v", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,6 +11,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Create a new Orthanc client
 	// Replace with your Orthanc server URL and credentials
 	client, err := gorthanc.NewClient(
@@ -26,7 +29,7 @@ func main() {
 	params := &types.PatientQueryParams{
 		Limit: 5,		
 	}
-	patients, err := client.GetPatients(params)
+	patients, err := client.GetPatients(ctx, params)
 	if err != nil {
 		log.Fatalf("Failed to get patients: %v", err)
 	}
@@ -37,7 +40,7 @@ func main() {
 	// Example: GetPatientDetails
 
 	for _, patientID := range patients {
-		patientDetails, err := client.GetPatientDetails(patientID)		
+		patientDetails, err := client.GetPatientDetails(ctx, patientID)		
 		if err != nil {
 			log.Fatalf("Failed to get patient details: %v ", err)
 		}
@@ -59,7 +62,7 @@ func main() {
 			Permissive: gorthanc.BoolPtr(false),
 			KeepSource: gorthanc.BoolPtr(true),
 		}
-		anonymizedPatient, err := client.AnonymizePatient(patients[0], anonymizeRequest)
+		anonymizedPatient, err := client.AnonymizePatient(ctx, patients[0], anonymizeRequest)
 		if err != nil {
 			log.Fatalf("Failed to anonymize series: %v", err)
 		}
@@ -74,7 +77,7 @@ func main() {
 	// Example: DeletePatient
 
 	if len(patients) > 0 {
-		err := client.DeletePatient(anonymizedPatientID)
+		err := client.DeletePatient(ctx, anonymizedPatientID)
 		if err != nil { 
 			log.Fatalf("Failed to delete patient: %v", err)
 		}
@@ -86,7 +89,7 @@ func main() {
 	// Example GetPatientStatistics
 
 	if len(patients) > 0 {
-		stats, err := client.GetPatientStatistics(patients[0])
+		stats, err := client.GetPatientStatistics(ctx, patients[0])
 		if err != nil {
 			log.Fatalf("Failed get series stats: %v", err)
 		}
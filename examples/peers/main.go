@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,6 +11,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	client, err := gorthanc.NewClient(
 		"http://localhost:8042",
 		gorthanc.WithBasicAuth("orthanc", "orthanc"),
@@ -19,7 +22,7 @@ func main() {
 	}
 
 	// Example: GetPeers
-	peers, err := client.GetPeers()
+	peers, err := client.GetPeers(ctx)
 	if err != nil {
 		log.Fatalf("Failed to get peers: %v", err)
 	}
@@ -27,7 +30,7 @@ func main() {
 
 	// Example: GetPeerDetails
 	if len(peers) > 0 {
-		peer, err := client.GetPeerDetails(peers[0])
+		peer, err := client.GetPeerDetails(ctx, peers[0])
 		if err != nil {
 			log.Fatalf("Failed to get peer details: %v", err)
 		}
@@ -38,7 +41,7 @@ func main() {
 
 	// Example: GetPeerSystem (test connectivity)
 	if len(peers) > 0 {
-		system, err := client.GetPeerSystem(peers[0])
+		system, err := client.GetPeerSystem(ctx, peers[0])
 		if err != nil {
 			fmt.Printf("Peer not reachable: %v\n", err)
 		} else {
@@ -53,13 +56,13 @@ func main() {
 		Username: "orthanc",
 		Password: "orthanc",
 	}
-	err = client.CreateOrUpdatePeer("TEST_PEER", request)
+	err = client.CreateOrUpdatePeer(ctx, "TEST_PEER", request)
 	if err != nil {
 		log.Fatalf("Failed to create peer: %v", err)
 	}
 
 	// Example: StoreToPeer (single resource)
-	err = client.StoreToPeer("TEST_PEER", "0f74e061-061039a7-aa467254-1f777b3f-480dede3")
+	err = client.StoreToPeer(ctx, "TEST_PEER", "0f74e061-061039a7-aa467254-1f777b3f-480dede3")
 	if err != nil {
 		log.Fatalf("Failed to store to peer: %v", err)
 	}
@@ -70,7 +73,7 @@ func main() {
 		Compress:    gorthanc.BoolPtr(true),
 		Synchronous: gorthanc.BoolPtr(true),
 	}
-	storeResult, err := client.StoreToPeerWithOptions("TEST_PEER", storeRequest)
+	storeResult, err := client.StoreToPeerWithOptions(ctx, "TEST_PEER", storeRequest)
 	if err != nil {
 		log.Fatalf("Failed to store to peer: %v", err)
 	}
@@ -78,7 +81,7 @@ func main() {
 	fmt.Println(string(jsonData))
 
 	// Example: DeletePeer
-	err = client.DeletePeer("TEST_PEER")
+	err = client.DeletePeer(ctx, "TEST_PEER")
 	if err != nil {
 		log.Fatalf("Failed to delete peer: %v", err)
 	}
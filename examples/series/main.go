@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,6 +13,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Create a new Orthanc client
 	// Replace with your Orthanc server URL and credentials
 	client, err := gorthanc.NewClient(
@@ -28,7 +31,7 @@ func main() {
 	params := &types.SeriesQueryParams{
 		Limit: 5,
 	}
-	series, err := client.GetSeries(params)
+	series, err := client.GetSeries(ctx, params)
 	if err != nil {
 		log.Fatalf("Failed to get series: %v", err)
 	}
@@ -43,7 +46,7 @@ func main() {
 		Expand: true,
 	}
 	
-	expandedSeries, err := client.GetSeriesExpanded(expandParams)
+	expandedSeries, err := client.GetSeriesExpanded(ctx, expandParams)
 	if err != nil {
 		log.Fatalf("Failed to get series: %v", err)
 	}
@@ -61,7 +64,7 @@ func main() {
 	// Example: GetSeriesDetail
 
 	for _, serieId := range series[:min(5, len(series))] {
-		serieDetails, err := client.GetSeriesDetail(serieId)
+		serieDetails, err := client.GetSeriesDetail(ctx, serieId)
 		if err != nil { 
 			log.Fatalf("Failed to get serie details: %v ", err)
 		}
@@ -76,7 +79,7 @@ func main() {
 	// Example: DeleteSerie (Commented to not delete nothing)
 
 	// if len(series) > 1 {
-	// 	err := client.DeleteSeries(expandedSeries[1].ID)
+	// 	err := client.DeleteSeries(ctx, expandedSeries[1].ID)
 	// 	if err != nil {
 	// 		log.Fatalf("Failed to delete serie: %v", err)
 	// 	}
@@ -92,7 +95,7 @@ func main() {
 			Permissive: gorthanc.BoolPtr(false),
 			KeepSource: gorthanc.BoolPtr(true),
 		}
-		anonymizedSeries, err := client.AnonymizeSeries(expandedSeries[0].ID, anonymizeRequest)
+		anonymizedSeries, err := client.AnonymizeSeries(ctx, expandedSeries[0].ID, anonymizeRequest)
 		if err != nil {
 			log.Fatalf("Failed to anonymize series: %v", err)
 		}
@@ -105,7 +108,7 @@ func main() {
 	// Example: DownloadSeriesArchive
 
 	if len(expandedSeries) > 0 {
-		archive, err := client.DownloadSeriesArchive(expandedSeries[0].ID)
+		archive, err := client.DownloadSeriesArchive(ctx, expandedSeries[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to download series archive: %v", err)
 		}
@@ -127,7 +130,7 @@ func main() {
 
 	// Example: GetSeriesStatistics
 
-	stats, err := client.GetSeriesStatistics(expandedSeries[0].ID)
+	stats, err := client.GetSeriesStatistics(ctx, expandedSeries[0].ID)
 	if err != nil {
 		log.Fatalf("Failed get series stats: %v", err)
 	}
@@ -143,7 +146,7 @@ func main() {
 	// Example: GetSeriesInstances
 
 	if len(expandedSeries) > 0 {
-		instanceIDs, err := client.GetSeriesInstances(expandedSeries[0].ID)
+		instanceIDs, err := client.GetSeriesInstances(ctx, expandedSeries[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to get series instances: %v", err)
 		}
@@ -154,7 +157,7 @@ func main() {
 	// Example: GetSeriesInstancesExpanded
 
 	if len(expandedSeries) > 0 {
-		instances, err := client.GetSeriesInstancesExpanded(expandedSeries[0].ID)
+		instances, err := client.GetSeriesInstancesExpanded(ctx, expandedSeries[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to get expanded series instances: %v", err)
 		}
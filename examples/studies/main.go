@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,6 +14,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Create a new Orthanc client
 	// Replace with your Orthanc server URL and credentials
 	client, err := gorthanc.NewClient(
@@ -26,7 +30,7 @@ func main() {
 	params := &types.StudiesQueryParams{
 		Limit: 5,
 	}
-	studies, err := client.GetStudies(params)
+	studies, err := client.GetStudies(ctx, params)
 	if err != nil {
 		log.Fatalf("Failed to get studies: %v", err)
 	}
@@ -39,7 +43,7 @@ func main() {
 		Expand: true,
 	}
 	
-	expandedStudies, err := client.GetStudiesExpanded(expandParams)
+	expandedStudies, err := client.GetStudiesExpanded(ctx, expandParams)
 	if err != nil {
 		log.Fatalf("Failed to get studies: %v", err)
 	}
@@ -53,10 +57,24 @@ func main() {
 		fmt.Println(string(jsonData))	
 	}
 
+	// Example: IterStudies, pages through all the studies 100 at a time
+
+	count := 0
+	for studyID, err := range client.IterStudies(ctx, &types.StudiesQueryParams{Limit: 100}) {
+		if err != nil {
+			log.Fatalf("Failed to iterate studies: %v", err)
+		}
+		if count < 3 {
+			fmt.Println(studyID)
+		}
+		count++
+	}
+	fmt.Printf("Iterated over %d studies \n", count)
+
 	// Example: GetStudy
 
 	for _, studyId := range studies {
-		studyDetails, err := client.GetStudy(studyId)
+		studyDetails, err := client.GetStudy(ctx, studyId)
 		if err != nil { 
 			log.Fatalf("Failed to get study details: %v ", err)
 		}
@@ -70,7 +88,7 @@ func main() {
 	// Example: DeleteStudy
 
 	// if len(studies) > 1 {
-	// 	err := client.DeleteStudy(expandedStudies[1].ID)
+	// 	err := client.DeleteStudy(ctx, expandedStudies[1].ID)
 	// 	if err != nil {
 	// 		log.Fatalf("Failed to delet study: %v", err)
 	// 	}
@@ -85,7 +103,7 @@ func main() {
 			Permissive: gorthanc.BoolPtr(false),
 			KeepSource: gorthanc.BoolPtr(true),
 		}
-		anonymizedStudy, err := client.AnonymizeStudy(expandedStudies[0].ID, anonymizeRequest)
+		anonymizedStudy, err := client.AnonymizeStudy(ctx, expandedStudies[0].ID, anonymizeRequest)
 		if err != nil {
 			log.Fatalf("Failed to anonymize study: %v", err)
 		}
@@ -94,10 +112,32 @@ func main() {
 		fmt.Println(string(jsonData))
 	}
 
+	// Example: AnonymizeStudyAsync
+
+	if len(expandedStudies) > 0 {
+		anonymizeRequest := &types.StudyAnonymizeRequest{
+			KeepSource: gorthanc.BoolPtr(true),
+		}
+		job, err := client.AnonymizeStudyAsync(ctx, expandedStudies[0].ID, anonymizeRequest)
+		if err != nil {
+			log.Fatalf("Failed to submit study anonymization: %v", err)
+		}
+
+		result, err := job.Wait(ctx, &gorthanc.WaitForJobOptions{
+			OnProgress: func(j *types.Job) {
+				fmt.Printf("Anonymization job %s: %s (%d%%)\n", j.ID, j.State, j.Progress)
+			},
+		})
+		if err != nil {
+			log.Fatalf("Failed to anonymize study: %v", err)
+		}
+		fmt.Printf("Anonymized study: %v\n", result.Content["ID"])
+	}
+
 	// Example: DownloadStudyArchive
 
 	if len(expandedStudies) > 0 {
-		archive, err := client.DownloadStudyArchive(expandedStudies[0].ID)
+		archive, err := client.DownloadStudyArchive(ctx, expandedStudies[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to download study archive: %v", err)
 		}
@@ -116,10 +156,28 @@ func main() {
 		fmt.Printf("Study archive downloaded to: study_%s.zip\n", expandedStudies[0].ID)
 	}
 
+	// Example: SaveArchiveToFile, with progress reporting, checksum and resume support
+
+	if len(expandedStudies) > 0 {
+		result, err := client.SaveArchiveToFile(ctx, types.ResourceLevelStudy, expandedStudies[0].ID, types.ArchiveFormatMedia,
+			"tmp/study_"+expandedStudies[0].ID+"_media.zip",
+			&gorthanc.DownloadOptions{
+				Resume: true,
+				Hash:   sha256.New(),
+				OnProgress: func(written, total int64) {
+					fmt.Printf("\rDownloaded %d bytes", written)
+				},
+			})
+		if err != nil {
+			log.Fatalf("Failed to save study media: %v", err)
+		}
+		fmt.Printf("\nStudy media saved (%d bytes, sha256 %s)\n", result.Written, result.Checksum)
+	}
+
 	// Example: GetStudySeries
 
 	if len(expandedStudies) > 0 {
-		seriesIDs, err := client.GetStudySeries(expandedStudies[0].ID)
+		seriesIDs, err := client.GetStudySeries(ctx, expandedStudies[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to get study series: %v", err)
 		}
@@ -130,7 +188,7 @@ func main() {
 	// Example: GetStudySeriesExpanded
 
 	if len(expandedStudies) > 0 {
-		series, err := client.GetStudySeriesExpanded(expandedStudies[0].ID)
+		series, err := client.GetStudySeriesExpanded(ctx, expandedStudies[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to get expanded study series: %v", err)
 		}
@@ -148,7 +206,7 @@ func main() {
 	// Example: GetStudyInstances
 
 	if len(expandedStudies) > 0 {
-		instanceIDs, err := client.GetStudyInstances(expandedStudies[0].ID)
+		instanceIDs, err := client.GetStudyInstances(ctx, expandedStudies[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to get study instances: %v", err)
 		}
@@ -159,7 +217,7 @@ func main() {
 	// Example: GetStudyInstancesExpanded
 
 	if len(expandedStudies) > 0 {
-		instances, err := client.GetStudyInstancesExpanded(expandedStudies[0].ID)
+		instances, err := client.GetStudyInstancesExpanded(ctx, expandedStudies[0].ID)
 		if err != nil {
 			log.Fatalf("Failed to get expanded study instances: %v", err)
 		}
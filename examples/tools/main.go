@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,6 +11,8 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Create a new Orthanc client
 	// Replace with your Orthanc server URL and credentials
 	client, err := gorthanc.NewClient(
@@ -26,7 +29,7 @@ func main() {
 		Level: types.ResourceLevelPatient,
 		Query: map[string]string{},
 	}
-	patientIDs, err := client.Find(findPatientsRequest)
+	patientIDs, err := client.Find(ctx, findPatientsRequest)
 	if err != nil {
 		log.Fatalf("Failed to find patients: %v", err)
 	}
@@ -43,7 +46,7 @@ func main() {
 			"PatientName": "*",
 		},
 	}
-	results, err := client.Find(findByNameRequest)
+	results, err := client.Find(ctx, findByNameRequest)
 	if err != nil {
 		log.Fatalf("Failed to find patients by name: %v", err)
 	}
@@ -60,7 +63,7 @@ func main() {
 		Query: map[string]string{},
 		Limit: &limit,
 	}
-	studyIDs, err := client.Find(findStudiesRequest)
+	studyIDs, err := client.Find(ctx, findStudiesRequest)
 	if err != nil {
 		log.Fatalf("Failed to find studies: %v", err)
 	}
@@ -77,7 +80,7 @@ func main() {
 			"StudyDate": "20240101-20241231",
 		},
 	}
-	dateResults, err := client.Find(findByDateRequest)
+	dateResults, err := client.Find(ctx, findByDateRequest)
 	if err != nil {
 		log.Fatalf("Failed to find studies by date: %v", err)
 	}
@@ -94,7 +97,7 @@ func main() {
 			"Modality": "CT",
 		},
 	}
-	modalityResults, err := client.Find(findByModalityRequest)
+	modalityResults, err := client.Find(ctx, findByModalityRequest)
 	if err != nil {
 		log.Fatalf("Failed to find series by modality: %v", err)
 	}
@@ -111,7 +114,7 @@ func main() {
 		Query: map[string]string{},
 		Limit: &instanceLimit,
 	}
-	instanceIDs, err := client.Find(findInstancesRequest)
+	instanceIDs, err := client.Find(ctx, findInstancesRequest)
 	if err != nil {
 		log.Fatalf("Failed to find instances: %v", err)
 	}
@@ -128,7 +131,7 @@ func main() {
 		Query: map[string]string{},
 		Limit: &expandLimit,
 	}
-	expandedPatients, err := client.FindExpanded(findExpandedRequest)
+	expandedPatients, err := client.FindExpanded(ctx, findExpandedRequest)
 	if err != nil {
 		log.Fatalf("Failed to find expanded patients: %v", err)
 	}
@@ -154,7 +157,7 @@ func main() {
 		Query: map[string]string{},
 		Limit: &studyExpandLimit,
 	}
-	expandedStudies, err := client.FindExpanded(findStudiesExpandedRequest)
+	expandedStudies, err := client.FindExpanded(ctx, findStudiesExpandedRequest)
 	if err != nil {
 		log.Fatalf("Failed to find expanded studies: %v", err)
 	}
@@ -173,28 +176,28 @@ func main() {
 
 	// Example 9: Get and Set Log Level
 	fmt.Println("=== Example 9: Get and Set Log Level ===")
-	currentLevel, err := client.GetLogLevel()
+	currentLevel, err := client.GetLogLevel(ctx)
 	if err != nil {
 		log.Fatalf("Failed to get log level: %v", err)
 	}
 	fmt.Printf("Current log level: %s\n", currentLevel)
 
 	// Set to verbose
-	err = client.SetLogLevel(types.LogLevelVerbose)
+	err = client.SetLogLevel(ctx, types.LogLevelVerbose)
 	if err != nil {
 		log.Fatalf("Failed to set log level to verbose: %v", err)
 	}
 	fmt.Println("Log level set to: verbose")
 
 	// Verify the change
-	newLevel, err := client.GetLogLevel()
+	newLevel, err := client.GetLogLevel(ctx)
 	if err != nil {
 		log.Fatalf("Failed to get log level: %v", err)
 	}
 	fmt.Printf("New log level: %s\n", newLevel)
 
 	// Restore to original level
-	err = client.SetLogLevel(currentLevel)
+	err = client.SetLogLevel(ctx, currentLevel)
 	if err != nil {
 		log.Fatalf("Failed to restore log level: %v", err)
 	}
@@ -206,7 +209,7 @@ func main() {
 	// Uncomment only if you want to test the reset functionality
 	/*
 	fmt.Println("=== Example 10: Reset Orthanc ===")
-	err = client.Reset()
+	err = client.Reset(ctx)
 	if err != nil {
 		log.Fatalf("Failed to reset Orthanc: %v", err)
 	}
@@ -219,7 +222,7 @@ func main() {
 	// Uncomment only if you want to test the shutdown functionality
 	/*
 	fmt.Println("=== Example 11: Shutdown Orthanc ===")
-	err = client.Shutdown()
+	err = client.Shutdown(ctx)
 	if err != nil {
 		log.Fatalf("Failed to shutdown Orthanc: %v", err)
 	}
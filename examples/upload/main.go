@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/proencaj/gorthanc"
+)
+
+func main() {
+	ctx := context.Background()
+
+	if len(os.Args) < 2 {
+		log.Fatal("Usage: upload <directory or zip file>")
+	}
+	source := os.Args[1]
+
+	client, err := gorthanc.NewClient(
+		"http://localhost:8042",
+		gorthanc.WithBasicAuth("orthanc", "orthanc"),
+		gorthanc.WithRetryPolicy(gorthanc.RetryPolicy{MaxRetries: 3, RetryNonIdempotent: true}),
+	)
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	uploader := client.NewBulkUploader(&gorthanc.BulkUploadOptions{
+		Workers: 8,
+		OnFile: func(result gorthanc.UploadFileResult) {
+			if result.Err != nil {
+				fmt.Printf("%-14s %s: %v\n", result.Status, result.Name, result.Err)
+				return
+			}
+			fmt.Printf("%-14s %s\n", result.Status, result.Name)
+		},
+	})
+
+	info, err := os.Stat(source)
+	if err != nil {
+		log.Fatalf("Failed to stat %s: %v", source, err)
+	}
+
+	// Example: UploadDirectory / UploadZip
+	var summary *gorthanc.BulkUploadSummary
+	if info.IsDir() {
+		summary, err = uploader.UploadDirectory(ctx, source)
+	} else {
+		summary, err = uploader.UploadZip(ctx, source)
+	}
+	if err != nil {
+		log.Fatalf("Upload stopped: %v", err)
+	}
+
+	fmt.Println("\n=== Upload Summary ===")
+	fmt.Printf("Stored:          %d\n", summary.Succeeded)
+	fmt.Printf("Already stored:  %d\n", summary.AlreadyStored)
+	fmt.Printf("Filtered out:    %d\n", summary.FilteredOut)
+	fmt.Printf("Skipped:         %d\n", summary.Skipped)
+	fmt.Printf("Failed:          %d\n", summary.Failed)
+	fmt.Printf("Patients: %d, Studies: %d, Series: %d\n", len(summary.Patients), len(summary.Studies), len(summary.Series))
+}
@@ -1,8 +1,38 @@
 package gorthanc
 
+import (
+	"fmt"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
 // BoolPtr returns a pointer to the given bool value.
 // This is a helper function for creating pointers to bool literals,
 // which is commonly needed when working with API request structs.
 func BoolPtr(b bool) *bool {
 	return &b
 }
+
+// resourceLevelPath returns the REST API root of the given resource level
+func resourceLevelPath(level types.ResourceLevel) (string, error) {
+	switch level {
+	case types.ResourceLevelPatient:
+		return "patients", nil
+	case types.ResourceLevelStudy:
+		return "studies", nil
+	case types.ResourceLevelSeries:
+		return "series", nil
+	case types.ResourceLevelInstance:
+		return "instances", nil
+	}
+	return "", fmt.Errorf("unknown resource level: %q", level)
+}
+
+// resourcePath returns the path of a resource at the given level
+func resourcePath(level types.ResourceLevel, resourceID string) (string, error) {
+	root, err := resourceLevelPath(level)
+	if err != nil {
+		return "", err
+	}
+	return endpointPath(root, resourceID), nil
+}
@@ -1,25 +1,30 @@
 package gorthanc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
 	"io"
 	
 	"github.com/proencaj/gorthanc/types"
 )
 
 
-func (c *Client) GetAllInstances(params *types.InstancesQueryParams) ([]string, error) {
+func (c *Client) GetAllInstances(ctx context.Context, params *types.InstancesQueryParams) ([]string, error) {
 	path := "instances"
 
 	if params != nil {
 		path = c.buildInstancePath(path, params)
 	}	
 
+	// If expand is requested, we need to handle the response differently
+	if params != nil && params.Expand {
+		return nil, fmt.Errorf("for expanded results, use GetAllInstancesExpanded method")
+	}
+
 	var instanceIDs []string
-	if err := c.get(path, &instanceIDs); err != nil {
+	if err := c.get(ctx, path, &instanceIDs); err != nil {
 		return nil, err
 	}
 
@@ -27,11 +32,29 @@ func (c *Client) GetAllInstances(params *types.InstancesQueryParams) ([]string,
 }
 
 
-func (c *Client) GetInstanceDetails(instanceID string) (*types.Instance, error) {
+func (c *Client) GetAllInstancesExpanded(ctx context.Context, params *types.InstancesQueryParams) ([]types.Instance, error) {
+	query := types.InstancesQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	query.Expand = true
+
+	path := c.buildInstancePath("instances", &query)
+
+	var instances []types.Instance
+	if err := c.get(ctx, path, &instances); err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+
+func (c *Client) GetInstanceDetails(ctx context.Context, instanceID string) (*types.Instance, error) {
 	var instance types.Instance
-	path := fmt.Sprintf("instances/%s", instanceID)
+	path := endpointPath("instances", instanceID)
 
-	if err := c.get(path, &instance); err != nil {
+	if err := c.get(ctx, path, &instance); err != nil {
 		return nil, err
 	}
 
@@ -39,14 +62,19 @@ func (c *Client) GetInstanceDetails(instanceID string) (*types.Instance, error)
 }
 
 
-func (c *Client) DeleteInstance(instanceID string) error {
-	path := fmt.Sprintf("instances/%s", instanceID)
-	return c.delete(path, nil) 
+func (c *Client) DeleteInstance(ctx context.Context, instanceID string) error {
+	path := endpointPath("instances", instanceID)
+	return c.delete(ctx, path, nil) 
 }
 
 
-func (c *Client) UploadDicomFile(reader io.Reader) (*types.UploadDicomFileResponse, error) {
-	resp, err := c.doRequest(http.MethodPost, "instances", reader)
+// UploadDicomFile stores a DICOM file in Orthanc
+// Readers implementing io.Seeker (such as *os.File) can be replayed by the retry policy.
+func (c *Client) UploadDicomFile(ctx context.Context, reader io.Reader) (*types.UploadDicomFileResponse, error) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/dicom")
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodPost, "instances", reader, header)
 	if err != nil {
 		return nil, err
 	}
@@ -61,27 +89,38 @@ func (c *Client) UploadDicomFile(reader io.Reader) (*types.UploadDicomFileRespon
 }
 
 
-func (c *Client) AnonymizeInstance(instanceID string, anonymizeRequest *types.InstancesAnonymizeRequest) (*http.Response, error) {
-	path := fmt.Sprintf("instances/%s/anonymize", instanceID)
-	return c.postWithBodyAndRawResponse(path, anonymizeRequest)
+func (c *Client) AnonymizeInstance(ctx context.Context, instanceID string, anonymizeRequest *types.InstancesAnonymizeRequest) (*http.Response, error) {
+	path := endpointPath("instances", instanceID, "anonymize")
+	return c.postWithBodyAndRawResponse(ctx, path, anonymizeRequest)
 }
 
 
-func (c *Client) DownloadDicomFile(instanceID string) (*http.Response, error) {
-	path := fmt.Sprintf("instances/%s/file", instanceID)
-	return c.getWithRawResponse(path)
+// ModifyInstance modifies the DICOM tags of an instance
+// The modified DICOM file is streamed back and is not stored in Orthanc.
+// The caller is responsible for closing the response body.
+func (c *Client) ModifyInstance(ctx context.Context, instanceID string, modifyRequest *types.ModifyRequest) (*http.Response, error) {
+	path := endpointPath("instances", instanceID, "modify")
+	return c.postWithBodyAndRawResponse(ctx, path, modifyRequest)
 }
 
 
-func (c *Client) GetInstanceTags(instanceID string, params *types.GetInstanceTagsQueryParams) (map[string]interface{}, error) {
+// DownloadDicomFile downloads the raw DICOM file of the instance.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadDicomFile(ctx context.Context, instanceID string) (*http.Response, error) {
+	path := endpointPath("instances", instanceID, "file")
+	return c.getWithRawResponse(ctx, path)
+}
+
+
+func (c *Client) GetInstanceTags(ctx context.Context, instanceID string, params *types.GetInstanceTagsQueryParams) (map[string]interface{}, error) {
 	var tags map[string]interface{}
-	path := fmt.Sprintf("instances/%s/tags", instanceID)
+	path := endpointPath("instances", instanceID, "tags")
 
 	if params != nil {
 		path = c.buildInstanceTagsPath(path, params)
 	}
 
-	if err := c.get(path, &tags); err != nil {
+	if err := c.get(ctx, path, &tags); err != nil {
 		return nil, err
 	}
 
@@ -94,20 +133,15 @@ func (c *Client) buildInstancePath(basePath string, params *types.InstancesQuery
 		return basePath
 	}
 
-	// Build query string manually
-	queryParams := ""
-	separator := "?"
+	var query queryBuilder
 
-	if params.Since >= 0 {
-		queryParams += separator + "since=" + strconv.Itoa(params.Since)
-		separator = "&"
+	if params.Expand {
+		query.addFlag("expand")
 	}
 
-	if params.Limit >= 0 {
-		queryParams += separator + "limit=" + strconv.Itoa(params.Limit)
-	}
+	query.addPage(params.Since, params.Limit)
 
-	return basePath + queryParams
+	return basePath + query.encode()
 }
 
 func (c *Client) buildInstanceTagsPath(basePath string, params *types.GetInstanceTagsQueryParams) string {
@@ -115,22 +149,19 @@ func (c *Client) buildInstanceTagsPath(basePath string, params *types.GetInstanc
 		return basePath
 	}
 
-	// Build query string manually
-	queryParams := ""
-	separator := "?"
+	var query queryBuilder
 
 	if params.Short {
-		queryParams += separator + "short=true"
-		separator = "&"
+		query.add("short", "true")
 	}
 
 	if params.Simplify {
-		queryParams += separator + "simplify=true" 
+		query.add("simplify", "true")
 	}
 
 	if params.Whole {
-		queryParams += separator + "whole=true" 
+		query.add("whole", "true")
 	}
 
-	return basePath + queryParams
+	return basePath + query.encode()
 }
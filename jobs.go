@@ -0,0 +1,199 @@
+package gorthanc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+const (
+	defaultJobPollInterval    = 200 * time.Millisecond
+	defaultJobMaxPollInterval = 5 * time.Second
+)
+
+// WaitForJobOptions configures how WaitForJob polls a job
+type WaitForJobOptions struct {
+	// Delay before the first poll (default: 200ms)
+	InitialInterval time.Duration
+
+	// Upper bound for the delay between two polls (default: 5s)
+	MaxInterval time.Duration
+
+	// Called after every poll with the latest state of the job (optional)
+	OnProgress func(job *types.Job)
+}
+
+// JobHandle references a job submitted to Orthanc in asynchronous mode
+type JobHandle struct {
+	// Identifier of the job
+	ID string
+
+	// Path to access the job
+	Path string
+
+	client *Client
+}
+
+// Get retrieves the current details of the job
+func (h *JobHandle) Get(ctx context.Context) (*types.Job, error) {
+	return h.client.GetJob(ctx, h.ID)
+}
+
+// Wait blocks until the job succeeds or fails, see WaitForJob
+func (h *JobHandle) Wait(ctx context.Context, opts *WaitForJobOptions) (*types.Job, error) {
+	return h.client.WaitForJob(ctx, h.ID, opts)
+}
+
+// Pause pauses the job
+func (h *JobHandle) Pause(ctx context.Context) error {
+	return h.client.PauseJob(ctx, h.ID)
+}
+
+// Resume resumes the job
+func (h *JobHandle) Resume(ctx context.Context) error {
+	return h.client.ResumeJob(ctx, h.ID)
+}
+
+// Cancel cancels the job
+func (h *JobHandle) Cancel(ctx context.Context) error {
+	return h.client.CancelJob(ctx, h.ID)
+}
+
+// GetJobs lists the identifiers of all the jobs known to Orthanc
+// This endpoint implements the GET /jobs request
+func (c *Client) GetJobs(ctx context.Context) ([]string, error) {
+	var jobIDs []string
+	if err := c.get(ctx, "jobs", &jobIDs); err != nil {
+		return nil, err
+	}
+	return jobIDs, nil
+}
+
+// GetJobsExpanded lists all the jobs known to Orthanc with their details
+// This endpoint implements the GET /jobs?expand request
+func (c *Client) GetJobsExpanded(ctx context.Context) ([]types.Job, error) {
+	var jobs []types.Job
+	if err := c.get(ctx, "jobs?expand", &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// GetJob retrieves the details of a job
+// This endpoint implements the GET /jobs/{id} request
+func (c *Client) GetJob(ctx context.Context, jobID string) (*types.Job, error) {
+	var job types.Job
+	path := endpointPath("jobs", jobID)
+
+	if err := c.get(ctx, path, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// PauseJob pauses a pending or running job
+// This endpoint implements the POST /jobs/{id}/pause request
+func (c *Client) PauseJob(ctx context.Context, jobID string) error {
+	path := endpointPath("jobs", jobID, "pause")
+	return c.post(ctx, path, nil, nil)
+}
+
+// ResumeJob resumes a paused job
+// This endpoint implements the POST /jobs/{id}/resume request
+func (c *Client) ResumeJob(ctx context.Context, jobID string) error {
+	path := endpointPath("jobs", jobID, "resume")
+	return c.post(ctx, path, nil, nil)
+}
+
+// CancelJob cancels a job, which then ends up in the Failure state
+// This endpoint implements the POST /jobs/{id}/cancel request
+func (c *Client) CancelJob(ctx context.Context, jobID string) error {
+	path := endpointPath("jobs", jobID, "cancel")
+	return c.post(ctx, path, nil, nil)
+}
+
+// ResubmitJob resubmits a job that ended in the Failure state
+// This endpoint implements the POST /jobs/{id}/resubmit request
+func (c *Client) ResubmitJob(ctx context.Context, jobID string) error {
+	path := endpointPath("jobs", jobID, "resubmit")
+	return c.post(ctx, path, nil, nil)
+}
+
+// DownloadJobArchive downloads the ZIP archive produced by an archive or media job
+// This endpoint implements the GET /jobs/{id}/archive request
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadJobArchive(ctx context.Context, jobID string) (*http.Response, error) {
+	path := endpointPath("jobs", jobID, "archive")
+	return c.getWithRawResponse(ctx, path)
+}
+
+// submitJob posts a request that Orthanc runs in asynchronous mode and returns a handle to the created job
+func (c *Client) submitJob(ctx context.Context, path string, body interface{}) (*JobHandle, error) {
+	var result types.AsyncJobResponse
+	if err := c.post(ctx, path, body, &result); err != nil {
+		return nil, err
+	}
+
+	if result.ID == "" {
+		return nil, fmt.Errorf("failed to get job ID from response")
+	}
+
+	return &JobHandle{ID: result.ID, Path: result.Path, client: c}, nil
+}
+
+// WaitForJob polls a job until it reaches the Success or Failure state
+// The delay between two polls doubles after each attempt, up to opts.MaxInterval.
+// If the job fails, the job is returned along with a *JobError.
+// Waiting stops with the context error as soon as ctx is done.
+func (c *Client) WaitForJob(ctx context.Context, jobID string, opts *WaitForJobOptions) (*types.Job, error) {
+	interval := defaultJobPollInterval
+	maxInterval := defaultJobMaxPollInterval
+	if opts != nil {
+		if opts.InitialInterval > 0 {
+			interval = opts.InitialInterval
+		}
+		if opts.MaxInterval > 0 {
+			maxInterval = opts.MaxInterval
+		}
+	}
+
+	for {
+		job, err := c.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts != nil && opts.OnProgress != nil {
+			opts.OnProgress(job)
+		}
+
+		switch job.State {
+		case types.JobStateSuccess:
+			return job, nil
+		case types.JobStateFailure:
+			return job, &JobError{
+				JobID:            job.ID,
+				ErrorCode:        job.ErrorCode,
+				ErrorDescription: job.ErrorDescription,
+				ErrorDetails:     job.ErrorDetails,
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
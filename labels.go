@@ -0,0 +1,56 @@
+package gorthanc
+
+import (
+	"context"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// GetLabels lists the labels attached to a resource (Orthanc 1.12.0+)
+// This endpoint implements the GET /{level}/{id}/labels request
+func (c *Client) GetLabels(ctx context.Context, level types.ResourceLevel, resourceID string) ([]string, error) {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []string
+	if err := c.get(ctx, path+"/labels", &labels); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// AddLabel attaches a label to a resource (Orthanc 1.12.0+)
+// This endpoint implements the PUT /{level}/{id}/labels/{label} request
+// Labels may only contain alphanumeric characters, dashes and underscores.
+func (c *Client) AddLabel(ctx context.Context, level types.ResourceLevel, resourceID string, label string) error {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return err
+	}
+
+	return c.put(ctx, path+"/"+endpointPath("labels", label), nil, nil)
+}
+
+// RemoveLabel detaches a label from a resource (Orthanc 1.12.0+)
+// This endpoint implements the DELETE /{level}/{id}/labels/{label} request
+func (c *Client) RemoveLabel(ctx context.Context, level types.ResourceLevel, resourceID string, label string) error {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return err
+	}
+
+	return c.delete(ctx, path+"/"+endpointPath("labels", label), nil)
+}
+
+// GetAllLabels lists all the labels that are attached to at least one resource (Orthanc 1.12.0+)
+// This endpoint implements the GET /tools/labels request
+func (c *Client) GetAllLabels(ctx context.Context) ([]string, error) {
+	var labels []string
+	if err := c.get(ctx, "tools/labels", &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
@@ -0,0 +1,130 @@
+package gorthanc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// revisionHeader builds the headers carrying the expected revision of a metadata or attachment
+// Orthanc rejects the request with a 409 Conflict if the revision does not match,
+// which lets concurrent workers detect that they lost an update.
+func revisionHeader(revision string) http.Header {
+	header := http.Header{}
+	if revision != "" {
+		header.Set("If-Match", revision)
+	}
+	return header
+}
+
+// revisionFromResponse extracts the revision reported in the ETag header of a response
+func revisionFromResponse(resp *http.Response) string {
+	etag := strings.TrimPrefix(resp.Header.Get("ETag"), "W/")
+	return strings.Trim(etag, `"`)
+}
+
+// GetMetadataNames lists the names of the metadata attached to a resource
+// This endpoint implements the GET /{level}/{id}/metadata request
+func (c *Client) GetMetadataNames(ctx context.Context, level types.ResourceLevel, resourceID string) ([]string, error) {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if err := c.get(ctx, path+"/metadata", &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// GetMetadataExpanded retrieves all the metadata attached to a resource, indexed by name
+// This endpoint implements the GET /{level}/{id}/metadata?expand request
+func (c *Client) GetMetadataExpanded(ctx context.Context, level types.ResourceLevel, resourceID string) (map[string]string, error) {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata map[string]string
+	if err := c.get(ctx, path+"/metadata?expand", &metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// GetMetadata retrieves a metadata attached to a resource along with its revision
+// This endpoint implements the GET /{level}/{id}/metadata/{name} request
+func (c *Client) GetMetadata(ctx context.Context, level types.ResourceLevel, resourceID string, name string) (*types.Metadata, error) {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "text/plain")
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodGet, path+"/"+endpointPath("metadata", name), nil, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	value, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &types.Metadata{
+		Name:     name,
+		Value:    string(value),
+		Revision: revisionFromResponse(resp),
+	}, nil
+}
+
+// SetMetadata creates or updates a metadata attached to a resource
+// This endpoint implements the PUT /{level}/{id}/metadata/{name} request
+// When revision is not empty it is sent as If-Match, so the update fails with a 409 Conflict
+// if the metadata was modified in the meantime. It is required to update an existing
+// metadata when CheckRevisions is enabled on the server.
+func (c *Client) SetMetadata(ctx context.Context, level types.ResourceLevel, resourceID string, name string, value string, revision string) error {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return err
+	}
+
+	header := revisionHeader(revision)
+	header.Set("Content-Type", "text/plain")
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodPut, path+"/"+endpointPath("metadata", name), strings.NewReader(value), header)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// DeleteMetadata deletes a metadata attached to a resource
+// This endpoint implements the DELETE /{level}/{id}/metadata/{name} request
+// See SetMetadata for the handling of revision.
+func (c *Client) DeleteMetadata(ctx context.Context, level types.ResourceLevel, resourceID string, name string, revision string) error {
+	path, err := resourcePath(level, resourceID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodDelete, path+"/"+endpointPath("metadata", name), nil, revisionHeader(revision))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package gorthanc
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RoundTripFunc sends a single HTTP request and returns its response
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every outgoing request
+// A middleware may inspect or modify the request, call next, and inspect the response.
+// When a retry policy is configured, middlewares run once per attempt.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middlewares to the client
+// Middlewares are applied in order, the first one being the outermost.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// buildRoundTrip chains the client middlewares around the HTTP client
+func (c *Client) buildRoundTrip() RoundTripFunc {
+	next := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return c.httpClient.Do(req)
+	})
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+
+	if len(c.headers) == 0 {
+		return next
+	}
+
+	// Static headers usually carry API keys or tokens, so middlewares are told to redact them
+	staticHeaders := slices.Collect(maps.Keys(c.headers))
+	return func(req *http.Request) (*http.Response, error) {
+		ctx := context.WithValue(req.Context(), staticHeadersKey{}, staticHeaders)
+		return next(req.WithContext(ctx))
+	}
+}
+
+// staticHeadersKey is the context key of the names of the headers set through WithHeaders
+type staticHeadersKey struct{}
+
+// sensitiveHeaders lists the headers always redacted by LoggingMiddleware
+// "Token" is the default header of the Orthanc authorization plugin.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "Token"}
+
+// LoggingMiddleware logs every request and its outcome with the given logger
+// Credentials are never logged: user info in the URL, sensitive headers and the headers
+// set through WithHeaders are redacted.
+// Request headers are only logged at the Debug level.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.Redacted()),
+			}
+
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				attrs = append(attrs, slog.Any("headers", redactHeaders(req.Header, staticHeaderNames(ctx))))
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "orthanc request failed", attrs...)
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			logger.LogAttrs(ctx, levelForStatus(resp.StatusCode), "orthanc request", attrs...)
+
+			return resp, nil
+		}
+	}
+}
+
+// redactHeaders returns a copy of the headers with credentials replaced
+func redactHeaders(header http.Header, extra []string) http.Header {
+	redacted := header.Clone()
+	for _, name := range slices.Concat(sensitiveHeaders, extra) {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+// staticHeaderNames returns the names of the headers set through WithHeaders, if any
+func staticHeaderNames(ctx context.Context) []string {
+	names, _ := ctx.Value(staticHeadersKey{}).([]string)
+	return names
+}
+
+// levelForStatus picks the log level matching an HTTP status code
+func levelForStatus(statusCode int) slog.Level {
+	switch {
+	case statusCode >= 500:
+		return slog.LevelError
+	case statusCode >= 400:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
@@ -1,26 +1,30 @@
 package gorthanc
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/proencaj/gorthanc/types"
 )
 
 
-func (c *Client) GetModalities() ([]string, error) {
+func (c *Client) GetModalities(ctx context.Context) ([]string, error) {
 	var modalities []string
-	if err := c.get("modalities", &modalities); err != nil {
+	if err := c.get(ctx, "modalities", &modalities); err != nil {
 		return nil, err
 	}
 	return modalities, nil
 }
 
 
-func (c *Client) GetModalityDetails(modalityName string) (*types.Modality, error) {
+func (c *Client) GetModalityDetails(ctx context.Context, modalityName string) (*types.Modality, error) {
 	var modality types.Modality
-	path := fmt.Sprintf("modalities/%s/configuration", modalityName)
+	path := endpointPath("modalities", modalityName, "configuration")
 
-	if err := c.get(path, &modality); err != nil {
+	if err := c.get(ctx, path, &modality); err != nil {
 		return nil, err
 	}
 
@@ -28,8 +32,8 @@ func (c *Client) GetModalityDetails(modalityName string) (*types.Modality, error
 }
 
 
-func (c *Client) CreateOrUpdateModality(modalityName string, request *types.ModalityCreateRequest) error {
-	path := fmt.Sprintf("modalities/%s", modalityName)
+func (c *Client) CreateOrUpdateModality(ctx context.Context, modalityName string, request *types.ModalityCreateRequest) error {
+	path := endpointPath("modalities", modalityName)
 
 	// Convert the request to the format expected by Orthanc
 	// Orthanc expects an array: [AET, Host, Port, Manufacturer]
@@ -44,7 +48,7 @@ func (c *Client) CreateOrUpdateModality(modalityName string, request *types.Moda
 		modalityArray = append(modalityArray, request.Manufacturer)
 	}
 
-	if err := c.put(path, modalityArray, nil); err != nil {
+	if err := c.put(ctx, path, modalityArray, nil); err != nil {
 		return err
 	}
 
@@ -52,10 +56,10 @@ func (c *Client) CreateOrUpdateModality(modalityName string, request *types.Moda
 }
 
 
-func (c *Client) DeleteModality(modalityName string) error {
-	path := fmt.Sprintf("modalities/%s", modalityName)
+func (c *Client) DeleteModality(ctx context.Context, modalityName string) error {
+	path := endpointPath("modalities", modalityName)
 
-	if err := c.delete(path, nil); err != nil {
+	if err := c.delete(ctx, path, nil); err != nil {
 		return err
 	}
 
@@ -63,21 +67,53 @@ func (c *Client) DeleteModality(modalityName string) error {
 }
 
 
-func (c *Client) EchoModality(modalityName string) error {
-	path := fmt.Sprintf("modalities/%s/echo", modalityName)
+// EchoModality checks the connectivity with a modality by sending a C-ECHO request
+// A failed echo is reported in the result rather than as an error, errors are kept for
+// other failures (e.g. an unknown modality or an unreachable Orthanc).
+// This endpoint implements the POST /modalities/{id}/echo request
+func (c *Client) EchoModality(ctx context.Context, modalityName string, request *types.ModalityEchoRequest) (*types.ModalityEchoResult, error) {
+	if request == nil {
+		request = &types.ModalityEchoRequest{}
+	}
+	return c.echo(ctx, endpointPath("modalities", modalityName, "echo"), request)
+}
 
-	if err := c.post(path, nil, nil); err != nil {
-		return err
+// echo sends a C-ECHO request and measures its latency
+// Orthanc reports failed echoes with a 500 status.
+func (c *Client) echo(ctx context.Context, path string, request interface{}) (*types.ModalityEchoResult, error) {
+	start := time.Now()
+	err := c.post(ctx, path, request, nil)
+	result := &types.ModalityEchoResult{Latency: time.Since(start)}
+
+	if err == nil {
+		result.Success = true
+		return result, nil
 	}
 
-	return nil
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		return nil, err
+	}
+
+	switch {
+	case httpErr.Orthanc != nil && httpErr.Orthanc.Details != "":
+		result.ErrorMessage = httpErr.Orthanc.Details
+	case httpErr.Orthanc != nil:
+		result.ErrorMessage = httpErr.Orthanc.Error()
+	case strings.TrimSpace(httpErr.Body) != "":
+		result.ErrorMessage = strings.TrimSpace(httpErr.Body)
+	default:
+		result.ErrorMessage = httpErr.Status
+	}
+
+	return result, nil
 }
 
 
-func (c *Client) StoreToModality(modalityName, resourceID string) error {
-	path := fmt.Sprintf("modalities/%s/store", modalityName)
+func (c *Client) StoreToModality(ctx context.Context, modalityName, resourceID string) error {
+	path := endpointPath("modalities", modalityName, "store")
 
-	if err := c.post(path, resourceID, nil); err != nil {
+	if err := c.post(ctx, path, resourceID, nil); err != nil {
 		return err
 	}
 
@@ -85,11 +121,11 @@ func (c *Client) StoreToModality(modalityName, resourceID string) error {
 }
 
 
-func (c *Client) StoreToModalityWithOptions(modalityName string, request *types.ModalityStoreRequest) (*types.ModalityStoreResult, error) {
-	path := fmt.Sprintf("modalities/%s/store", modalityName)
+func (c *Client) StoreToModalityWithOptions(ctx context.Context, modalityName string, request *types.ModalityStoreRequest) (*types.ModalityStoreResult, error) {
+	path := endpointPath("modalities", modalityName, "store")
 
 	var result types.ModalityStoreResult
-	if err := c.post(path, request, &result); err != nil {
+	if err := c.post(ctx, path, request, &result); err != nil {
 		return nil, err
 	}
 
@@ -97,37 +133,23 @@ func (c *Client) StoreToModalityWithOptions(modalityName string, request *types.
 }
 
 
-func (c *Client) FindInModality(modalityName string, request *types.ModalityFindRequest) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("modalities/%s/query", modalityName)
-
-	var queryResponse map[string]interface{}
-	if err := c.post(path, request, &queryResponse); err != nil {
+// FindInModality runs a C-FIND query against a modality and returns its answers in the simplified format
+// Use QueryModality to keep the query, choose the format of the answers or retrieve them.
+func (c *Client) FindInModality(ctx context.Context, modalityName string, request *types.ModalityFindRequest) ([]types.ModalityFindResult, error) {
+	query, err := c.QueryModality(ctx, modalityName, request)
+	if err != nil {
 		return nil, err
 	}
 
-	// Extract the query ID from the response
-	queryID, ok := queryResponse["ID"].(string)
-	if !ok {
-		return nil, fmt.Errorf("failed to get query ID from response")
-	}
-
-	// TODO: Turn 'expand' and 'simplify' into new parameters or options, so the user can change based on what is needs
-	answersPath := fmt.Sprintf("queries/%s/answers?expand=true&simplify=true", queryID)
-	
-	var answers []map[string]interface{}
-	if err := c.get(answersPath, &answers); err != nil {
-		return nil, err
-	}
-
-	return answers, nil
+	return query.AnswersExpanded(ctx, &types.QueryAnswersOptions{Simplify: true})
 }
 
 
-func (c *Client) MoveFromModality(modalityName string, request *types.ModalityMoveRequest) (*types.ModalityMoveResult, error) {
-	path := fmt.Sprintf("modalities/%s/move", modalityName)
+func (c *Client) MoveFromModality(ctx context.Context, modalityName string, request *types.ModalityMoveRequest) (*types.ModalityMoveResult, error) {
+	path := endpointPath("modalities", modalityName, "move")
 
 	var result types.ModalityMoveResult
-	if err := c.post(path, request, &result); err != nil {
+	if err := c.post(ctx, path, request, &result); err != nil {
 		return nil, err
 	}
 
@@ -135,12 +157,72 @@ func (c *Client) MoveFromModality(modalityName string, request *types.ModalityMo
 }
 
 
-func (c *Client) GetFromModality(modalityName string, request *types.ModalityGetRequest) error {
-	path := fmt.Sprintf("modalities/%s/get", modalityName)
+func (c *Client) GetFromModality(ctx context.Context, modalityName string, request *types.ModalityGetRequest) error {
+	path := endpointPath("modalities", modalityName, "get")
 
-	if err := c.post(path, request, nil); err != nil {
+	if err := c.post(ctx, path, request, nil); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+
+// FindWorklist runs a modality worklist C-FIND query against a modality
+// This endpoint implements the POST /modalities/{id}/find-worklist request
+func (c *Client) FindWorklist(ctx context.Context, modalityName string, request *types.WorklistFindRequest) ([]types.WorklistItem, error) {
+	path := endpointPath("modalities", modalityName, "find-worklist")
+
+	if request == nil {
+		request = &types.WorklistFindRequest{}
+	}
+
+	var items []types.WorklistItem
+	if err := c.post(ctx, path, buildWorklistQuery(request), &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// worklistQuery is the body of a find-worklist request
+type worklistQuery struct {
+	Query   map[string]interface{} `json:"Query"`
+	Timeout int                    `json:"Timeout,omitempty"`
+}
+
+// buildWorklistQuery converts a worklist request to the query expected by Orthanc
+// Every attribute of types.WorklistItem is requested, as Orthanc only returns the requested attributes.
+func buildWorklistQuery(request *types.WorklistFindRequest) *worklistQuery {
+	step := map[string]interface{}{
+		"Modality":                          request.Modality,
+		"ScheduledStationAETitle":           request.ScheduledStationAETitle,
+		"ScheduledStationName":              "",
+		"ScheduledProcedureStepStartDate":   request.ScheduledProcedureStepStartDate,
+		"ScheduledProcedureStepStartTime":   "",
+		"ScheduledPerformingPhysicianName":  "",
+		"ScheduledProcedureStepDescription": "",
+		"ScheduledProcedureStepID":          "",
+	}
+	for keyword, value := range request.ScheduledProcedureStepQuery {
+		step[keyword] = value
+	}
+
+	query := map[string]interface{}{
+		"PatientName":                    request.PatientName,
+		"PatientID":                      request.PatientID,
+		"PatientBirthDate":               "",
+		"PatientSex":                     "",
+		"AccessionNumber":                request.AccessionNumber,
+		"StudyInstanceUID":               "",
+		"ReferringPhysicianName":         "",
+		"RequestedProcedureID":           "",
+		"RequestedProcedureDescription":  "",
+		"ScheduledProcedureStepSequence": []interface{}{step},
+	}
+	for keyword, value := range request.Query {
+		query[keyword] = value
+	}
+
+	return &worklistQuery{Query: query, Timeout: request.Timeout}
+}
@@ -0,0 +1,195 @@
+package gorthanc
+
+import (
+	"context"
+	"iter"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// defaultPageSize is the number of resources fetched per request by the iterators
+// when the query parameters do not set a Limit
+const defaultPageSize = 100
+
+// paginate iterates over the results of fetch, one page at a time
+// Iteration stops after the first error, which is yielded with the zero value of T.
+// Every run of the iterator starts over from start.
+func paginate[T any](start, pageSize int, fetch func(since, limit int) ([]T, error)) iter.Seq2[T, error] {
+	if start < 0 {
+		start = 0
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		since := start
+		for {
+			page, err := fetch(since, pageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(page) < pageSize {
+				return
+			}
+			since += len(page)
+		}
+	}
+}
+
+// IterStudies iterates over the identifiers of all the studies, fetching them page by page
+// params.Since is the index of the first study and params.Limit the page size (default: 100).
+// Breaking out of the loop stops fetching further pages.
+func (c *Client) IterStudies(ctx context.Context, params *types.StudiesQueryParams) iter.Seq2[string, error] {
+	query := types.StudiesQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	query.Expand = false
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]string, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetStudies(ctx, &page)
+	})
+}
+
+// IterStudiesExpanded iterates over the details of all the studies, fetching them page by page
+// See IterStudies for the handling of params.
+func (c *Client) IterStudiesExpanded(ctx context.Context, params *types.StudiesQueryParams) iter.Seq2[types.Study, error] {
+	query := types.StudiesQueryParams{}
+	if params != nil {
+		query = *params
+	}
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]types.Study, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetStudiesExpanded(ctx, &page)
+	})
+}
+
+// IterSeries iterates over the identifiers of all the series, fetching them page by page
+// See IterStudies for the handling of params.
+func (c *Client) IterSeries(ctx context.Context, params *types.SeriesQueryParams) iter.Seq2[string, error] {
+	query := types.SeriesQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	query.Expand = false
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]string, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetSeries(ctx, &page)
+	})
+}
+
+// IterSeriesExpanded iterates over the details of all the series, fetching them page by page
+// See IterStudies for the handling of params.
+func (c *Client) IterSeriesExpanded(ctx context.Context, params *types.SeriesQueryParams) iter.Seq2[types.Series, error] {
+	query := types.SeriesQueryParams{}
+	if params != nil {
+		query = *params
+	}
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]types.Series, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetSeriesExpanded(ctx, &page)
+	})
+}
+
+// IterInstances iterates over the identifiers of all the instances, fetching them page by page
+// See IterStudies for the handling of params.
+func (c *Client) IterInstances(ctx context.Context, params *types.InstancesQueryParams) iter.Seq2[string, error] {
+	query := types.InstancesQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	query.Expand = false
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]string, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetAllInstances(ctx, &page)
+	})
+}
+
+// IterInstancesExpanded iterates over the details of all the instances, fetching them page by page
+// See IterStudies for the handling of params.
+func (c *Client) IterInstancesExpanded(ctx context.Context, params *types.InstancesQueryParams) iter.Seq2[types.Instance, error] {
+	query := types.InstancesQueryParams{}
+	if params != nil {
+		query = *params
+	}
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]types.Instance, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetAllInstancesExpanded(ctx, &page)
+	})
+}
+
+// IterPatients iterates over the identifiers of all the patients, fetching them page by page
+// See IterStudies for the handling of params.
+func (c *Client) IterPatients(ctx context.Context, params *types.PatientQueryParams) iter.Seq2[string, error] {
+	query := types.PatientQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	query.Expand = false
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]string, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetPatients(ctx, &page)
+	})
+}
+
+// IterPatientsExpanded iterates over the details of all the patients, fetching them page by page
+// See IterStudies for the handling of params.
+func (c *Client) IterPatientsExpanded(ctx context.Context, params *types.PatientQueryParams) iter.Seq2[types.Patient, error] {
+	query := types.PatientQueryParams{}
+	if params != nil {
+		query = *params
+	}
+
+	return paginate(query.Since, query.Limit, func(since, limit int) ([]types.Patient, error) {
+		page := query
+		page.Since, page.Limit = since, limit
+		return c.GetPatientsExpanded(ctx, &page)
+	})
+}
+
+// IterFindExpanded iterates over the results of a /tools/find request, fetching them page by page
+// request.Since is the index of the first result and request.Limit the page size (default: 100).
+func (c *Client) IterFindExpanded(ctx context.Context, request *types.ToolsFindRequest) iter.Seq2[types.ToolsFindExpandedResource, error] {
+	query := types.ToolsFindRequest{}
+	if request != nil {
+		query = *request
+	}
+
+	since, pageSize := 0, 0
+	if query.Since != nil {
+		since = *query.Since
+	}
+	if query.Limit != nil {
+		pageSize = *query.Limit
+	}
+
+	return paginate(since, pageSize, func(since, limit int) ([]types.ToolsFindExpandedResource, error) {
+		page := query
+		page.Since, page.Limit = &since, &limit
+		return c.FindExpanded(ctx, &page)
+	})
+}
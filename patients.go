@@ -1,14 +1,15 @@
 package gorthanc
 
 import (
+	"context"
 	"fmt"
-	"strconv"
+	"net/http"
 
 	"github.com/proencaj/gorthanc/types"
 )
 
 
-func (c *Client) GetPatients(params *types.PatientQueryParams) ([]string, error) {
+func (c *Client) GetPatients(ctx context.Context, params *types.PatientQueryParams) ([]string, error) {
 	path := "patients"
 
 	if params != nil {
@@ -17,11 +18,11 @@ func (c *Client) GetPatients(params *types.PatientQueryParams) ([]string, error)
 
 	// If expand is requested, we need to handle the response differently
 	if params != nil && params.Expand {
-		return nil, fmt.Errorf("not implemented, use GetPatient for each patient to get details")
+		return nil, fmt.Errorf("for expanded results, use GetPatientsExpanded method")
 	}
 
 	var patientIDs []string
-	if err := c.get(path, &patientIDs); err != nil {
+	if err := c.get(ctx, path, &patientIDs); err != nil {
 		return nil, err
 	}
 
@@ -29,68 +30,141 @@ func (c *Client) GetPatients(params *types.PatientQueryParams) ([]string, error)
 }
 
 
-func (c *Client) GetPatientDetails(patientID string) (*types.Patient, error) {
+func (c *Client) GetPatientsExpanded(ctx context.Context, params *types.PatientQueryParams) ([]types.Patient, error) {
+	query := types.PatientQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	query.Expand = true
+
+	path := c.buildPatientsPath("patients", &query)
+
+	var patients []types.Patient
+	if err := c.get(ctx, path, &patients); err != nil {
+		return nil, err
+	}
+
+	return patients, nil
+}
+
+
+func (c *Client) GetPatientDetails(ctx context.Context, patientID string) (*types.Patient, error) {
 	var patient types.Patient
-	path := fmt.Sprintf("patients/%s", patientID)
+	path := endpointPath("patients", patientID)
 
-	if err := c.get(path, &patient); err != nil {
+	if err := c.get(ctx, path, &patient); err != nil {
 		return nil, err
 	}
 
 	return &patient, nil
 }
 
-func (c *Client) AnonymizePatient(studyID string, anonymizeRequest *types.PatientAnonymizeRequest) (*types.PatientAnonymizeResponse, error) {
+// AnonymizePatient anonymizes a patient synchronously
+// Patients with many studies may exceed the HTTP client timeout, use AnonymizePatientAsync for those.
+func (c *Client) AnonymizePatient(ctx context.Context, patientID string, anonymizeRequest *types.PatientAnonymizeRequest) (*types.PatientAnonymizeResponse, error) {
 	var result types.PatientAnonymizeResponse
-	path := fmt.Sprintf("patients/%s/anonymize", studyID)
-	anonymizeRequest.Asynchronous = BoolPtr(false)
+	path := endpointPath("patients", patientID, "anonymize")
+	request := types.PatientAnonymizeRequest{}
+	if anonymizeRequest != nil {
+		request = *anonymizeRequest
+	}
+	request.Asynchronous = BoolPtr(false)
 
-	if err := c.post(path, anonymizeRequest, &result); err != nil {
+	if err := c.post(ctx, path, &request, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
+// AnonymizePatientAsync submits the anonymization of a patient as an Orthanc job
+// Use the returned handle to wait for the job, its Content holds the ID of the new patient.
+func (c *Client) AnonymizePatientAsync(ctx context.Context, patientID string, anonymizeRequest *types.PatientAnonymizeRequest) (*JobHandle, error) {
+	path := endpointPath("patients", patientID, "anonymize")
+	request := types.PatientAnonymizeRequest{}
+	if anonymizeRequest != nil {
+		request = *anonymizeRequest
+	}
+	request.Asynchronous = BoolPtr(true)
+
+	return c.submitJob(ctx, path, &request)
+}
+
+// ModifyPatient modifies the DICOM tags of a patient synchronously
+// Replacing the PatientID requires Force to be set.
+// Orthanc creates a new patient, the source is kept unless KeepSource is set to false.
+func (c *Client) ModifyPatient(ctx context.Context, patientID string, modifyRequest *types.ModifyRequest) (*types.ModifyResponse, error) {
+	var result types.ModifyResponse
+	path := endpointPath("patients", patientID, "modify")
+	request := types.ModifyRequest{}
+	if modifyRequest != nil {
+		request = *modifyRequest
+	}
+	request.Asynchronous = BoolPtr(false)
+
+	if err := c.post(ctx, path, &request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ModifyPatientAsync submits the modification of a patient as an Orthanc job
+func (c *Client) ModifyPatientAsync(ctx context.Context, patientID string, modifyRequest *types.ModifyRequest) (*JobHandle, error) {
+	path := endpointPath("patients", patientID, "modify")
+	request := types.ModifyRequest{}
+	if modifyRequest != nil {
+		request = *modifyRequest
+	}
+	request.Asynchronous = BoolPtr(true)
+
+	return c.submitJob(ctx, path, &request)
+}
+
 // buildPatientsPath constructs the path with query parameters
 func (c *Client) buildPatientsPath(basePath string, params *types.PatientQueryParams) string {
 	if params == nil {
 		return basePath
 	}
 
-	// Build query string manually
-	queryParams := ""
-	separator := "?"
+	var query queryBuilder
 
 	if params.Expand {
-		queryParams += separator + "expand"
-		separator = "&"
+		query.addFlag("expand")
 	}
 
-	if params.Since >= 0 {
-		queryParams += separator + "since=" + strconv.Itoa(params.Since)
-		separator = "&"
-	}
-
-	if params.Limit >= 0 {
-		queryParams += separator + "limit=" + strconv.Itoa(params.Limit)
-	}
+	query.addPage(params.Since, params.Limit)
 
-	return basePath + queryParams
+	return basePath + query.encode()
 }
 
-func (c *Client) DeletePatient(patientID string) error {
-	path := fmt.Sprintf("patients/%s", patientID)
-	return c.delete(path, nil)
+func (c *Client) DeletePatient(ctx context.Context, patientID string) error {
+	path := endpointPath("patients", patientID)
+	return c.delete(ctx, path, nil)
 }
 
-func (c *Client) GetPatientStatistics(patientID string) (*types.PatientStatistics, error) {
+func (c *Client) GetPatientStatistics(ctx context.Context, patientID string) (*types.PatientStatistics, error) {
 	var stats types.PatientStatistics
-	path := fmt.Sprintf("patients/%s/statistics", patientID)
+	path := endpointPath("patients", patientID, "statistics")
 
-	if err := c.get(path, &stats); err != nil {
+	if err := c.get(ctx, path, &stats); err != nil {
 		return nil, err
 	}
 
 	return &stats, nil
 }
+
+
+// DownloadPatientArchive downloads a ZIP archive of the patient.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadPatientArchive(ctx context.Context, patientID string) (*http.Response, error) {
+	path := endpointPath("patients", patientID, "archive")
+	return c.getWithRawResponse(ctx, path)
+}
+
+// DownloadPatientMedia downloads a ZIP archive of the patient containing a DICOMDIR.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadPatientMedia(ctx context.Context, patientID string) (*http.Response, error) {
+	path := endpointPath("patients", patientID, "media")
+	return c.getWithRawResponse(ctx, path)
+}
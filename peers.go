@@ -1,76 +1,76 @@
 package gorthanc
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/proencaj/gorthanc/types"
 )
 
-func (c *Client) GetPeers() ([]string, error) {
+func (c *Client) GetPeers(ctx context.Context) ([]string, error) {
 	var peers []string
-	if err := c.get("peers", &peers); err != nil {
+	if err := c.get(ctx, "peers", &peers); err != nil {
 		return nil, err
 	}
 	return peers, nil
 }
 
-func (c *Client) GetPeerDetails(peerName string) (*types.Peer, error) {
+func (c *Client) GetPeerDetails(ctx context.Context, peerName string) (*types.Peer, error) {
 	var peer types.Peer
-	path := fmt.Sprintf("peers/%s/configuration", peerName)
+	path := endpointPath("peers", peerName, "configuration")
 
-	if err := c.get(path, &peer); err != nil {
+	if err := c.get(ctx, path, &peer); err != nil {
 		return nil, err
 	}
 
 	return &peer, nil
 }
 
-func (c *Client) CreateOrUpdatePeer(peerName string, request *types.PeerCreateRequest) error {
-	path := fmt.Sprintf("peers/%s", peerName)
+func (c *Client) CreateOrUpdatePeer(ctx context.Context, peerName string, request *types.PeerCreateRequest) error {
+	path := endpointPath("peers", peerName)
 
-	if err := c.put(path, request, nil); err != nil {
+	if err := c.put(ctx, path, request, nil); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *Client) DeletePeer(peerName string) error {
-	path := fmt.Sprintf("peers/%s", peerName)
+func (c *Client) DeletePeer(ctx context.Context, peerName string) error {
+	path := endpointPath("peers", peerName)
 
-	if err := c.delete(path, nil); err != nil {
+	if err := c.delete(ctx, path, nil); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *Client) StoreToPeer(peerName, resourceID string) error {
-	path := fmt.Sprintf("peers/%s/store", peerName)
+func (c *Client) StoreToPeer(ctx context.Context, peerName, resourceID string) error {
+	path := endpointPath("peers", peerName, "store")
 
-	if err := c.post(path, resourceID, nil); err != nil {
+	if err := c.post(ctx, path, resourceID, nil); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *Client) StoreToPeerWithOptions(peerName string, request *types.PeerStoreRequest) (*types.PeerStoreResult, error) {
-	path := fmt.Sprintf("peers/%s/store", peerName)
+func (c *Client) StoreToPeerWithOptions(ctx context.Context, peerName string, request *types.PeerStoreRequest) (*types.PeerStoreResult, error) {
+	path := endpointPath("peers", peerName, "store")
 
 	var result types.PeerStoreResult
-	if err := c.post(path, request, &result); err != nil {
+	if err := c.post(ctx, path, request, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
-func (c *Client) GetPeerSystem(peerName string) (*types.SystemInfo, error) {
+func (c *Client) GetPeerSystem(ctx context.Context, peerName string) (*types.SystemInfo, error) {
 	var info types.SystemInfo
-	path := fmt.Sprintf("peers/%s/system", peerName)
+	path := endpointPath("peers", peerName, "system")
 
-	if err := c.get(path, &info); err != nil {
+	if err := c.get(ctx, path, &info); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,216 @@
+package gorthanc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+// QueryHandle references a query/retrieve query stored by Orthanc
+// Orthanc keeps a limited number of queries, the oldest ones are removed when the limit is reached.
+type QueryHandle struct {
+	// Identifier of the query
+	ID string
+
+	// Path to access the query
+	Path string
+
+	client *Client
+}
+
+// QueryModality runs a C-FIND query against a modality and keeps it for later use
+// This endpoint implements the POST /modalities/{id}/query request
+func (c *Client) QueryModality(ctx context.Context, modalityName string, request *types.ModalityFindRequest) (*QueryHandle, error) {
+	return c.createQuery(ctx, endpointPath("modalities", modalityName, "query"), request)
+}
+
+// GetQueries lists the identifiers of the queries stored by Orthanc
+// This endpoint implements the GET /queries request
+func (c *Client) GetQueries(ctx context.Context) ([]string, error) {
+	var queryIDs []string
+	if err := c.get(ctx, "queries", &queryIDs); err != nil {
+		return nil, err
+	}
+	return queryIDs, nil
+}
+
+// Query returns a handle to a query stored by Orthanc
+func (c *Client) Query(queryID string) *QueryHandle {
+	return &QueryHandle{ID: queryID, Path: "/" + endpointPath("queries", queryID), client: c}
+}
+
+// createQuery posts a query and returns a handle to the created query
+func (c *Client) createQuery(ctx context.Context, path string, body interface{}) (*QueryHandle, error) {
+	var result types.ModalityQueryResponse
+	if err := c.post(ctx, path, body, &result); err != nil {
+		return nil, err
+	}
+
+	if result.ID == "" {
+		return nil, fmt.Errorf("failed to get query ID from response")
+	}
+
+	return &QueryHandle{ID: result.ID, Path: result.Path, client: c}, nil
+}
+
+// path returns the path of a sub-resource of the query
+func (q *QueryHandle) path(segments ...string) string {
+	return endpointPath(append([]string{"queries", q.ID}, segments...)...)
+}
+
+// Level returns the level of the query
+// This endpoint implements the GET /queries/{id}/level request
+func (q *QueryHandle) Level(ctx context.Context) (types.ResourceLevel, error) {
+	level, err := q.client.getPlainText(ctx, q.path("level"))
+	if err != nil {
+		return "", err
+	}
+	return types.ResourceLevel(level), nil
+}
+
+// Modality returns the name of the modality the query was sent to
+// This endpoint implements the GET /queries/{id}/modality request
+func (q *QueryHandle) Modality(ctx context.Context) (string, error) {
+	return q.client.getPlainText(ctx, q.path("modality"))
+}
+
+// Request returns the DICOM tags of the query as it was sent to the modality
+// This endpoint implements the GET /queries/{id}/query request
+func (q *QueryHandle) Request(ctx context.Context, opts *types.QueryAnswersOptions) (types.ModalityFindResult, error) {
+	var request types.ModalityFindResult
+	if err := q.client.get(ctx, q.path("query")+buildQueryAnswersQuery(false, opts), &request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// Answers lists the indexes of the answers to the query
+// This endpoint implements the GET /queries/{id}/answers request
+func (q *QueryHandle) Answers(ctx context.Context) ([]string, error) {
+	var indexes []string
+	if err := q.client.get(ctx, q.path("answers"), &indexes); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+// AnswersExpanded returns the DICOM tags of all the answers to the query
+// This endpoint implements the GET /queries/{id}/answers?expand request
+func (q *QueryHandle) AnswersExpanded(ctx context.Context, opts *types.QueryAnswersOptions) ([]types.ModalityFindResult, error) {
+	var answers []types.ModalityFindResult
+	if err := q.client.get(ctx, q.path("answers")+buildQueryAnswersQuery(true, opts), &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// Answer returns the DICOM tags of a single answer to the query
+// This endpoint implements the GET /queries/{id}/answers/{index}/content request
+func (q *QueryHandle) Answer(ctx context.Context, index int, opts *types.QueryAnswersOptions) (types.ModalityFindResult, error) {
+	var answer types.ModalityFindResult
+	path := q.path("answers", strconv.Itoa(index), "content") + buildQueryAnswersQuery(false, opts)
+	if err := q.client.get(ctx, path, &answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// Retrieve retrieves all the answers to the query synchronously
+// This endpoint implements the POST /queries/{id}/retrieve request
+func (q *QueryHandle) Retrieve(ctx context.Context, request *types.QueryRetrieveRequest) (*types.ModalityMoveResult, error) {
+	return q.retrieve(ctx, q.path("retrieve"), request)
+}
+
+// RetrieveAsync retrieves all the answers to the query as a job
+// This endpoint implements the POST /queries/{id}/retrieve request in asynchronous mode
+func (q *QueryHandle) RetrieveAsync(ctx context.Context, request *types.QueryRetrieveRequest) (*JobHandle, error) {
+	return q.retrieveAsync(ctx, q.path("retrieve"), request)
+}
+
+// RetrieveAnswer retrieves a single answer to the query synchronously
+// This endpoint implements the POST /queries/{id}/answers/{index}/retrieve request
+func (q *QueryHandle) RetrieveAnswer(ctx context.Context, index int, request *types.QueryRetrieveRequest) (*types.ModalityMoveResult, error) {
+	return q.retrieve(ctx, q.path("answers", strconv.Itoa(index), "retrieve"), request)
+}
+
+// RetrieveAnswerAsync retrieves a single answer to the query as a job
+// This endpoint implements the POST /queries/{id}/answers/{index}/retrieve request in asynchronous mode
+func (q *QueryHandle) RetrieveAnswerAsync(ctx context.Context, index int, request *types.QueryRetrieveRequest) (*JobHandle, error) {
+	return q.retrieveAsync(ctx, q.path("answers", strconv.Itoa(index), "retrieve"), request)
+}
+
+func (q *QueryHandle) retrieve(ctx context.Context, path string, request *types.QueryRetrieveRequest) (*types.ModalityMoveResult, error) {
+	retrieveRequest := types.QueryRetrieveRequest{}
+	if request != nil {
+		retrieveRequest = *request
+	}
+	retrieveRequest.Asynchronous = BoolPtr(false)
+
+	var result types.ModalityMoveResult
+	if err := q.client.post(ctx, path, &retrieveRequest, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (q *QueryHandle) retrieveAsync(ctx context.Context, path string, request *types.QueryRetrieveRequest) (*JobHandle, error) {
+	retrieveRequest := types.QueryRetrieveRequest{}
+	if request != nil {
+		retrieveRequest = *request
+	}
+	retrieveRequest.Asynchronous = BoolPtr(true)
+
+	return q.client.submitJob(ctx, path, &retrieveRequest)
+}
+
+// QueryChildren queries the children of an answer at the given level, for hierarchical browsing of a remote modality
+// Studies can be queried from a patient answer, series from a study answer and instances from a series answer.
+// This endpoint implements the POST /queries/{id}/answers/{index}/query-{studies,series,instances} request
+func (q *QueryHandle) QueryChildren(ctx context.Context, index int, level types.ResourceLevel, request *types.QueryChildrenRequest) (*QueryHandle, error) {
+	var child string
+	switch level {
+	case types.ResourceLevelStudy:
+		child = "query-studies"
+	case types.ResourceLevelSeries:
+		child = "query-series"
+	case types.ResourceLevelInstance:
+		child = "query-instances"
+	default:
+		return nil, fmt.Errorf("cannot query children at the %q level", level)
+	}
+
+	if request == nil {
+		request = &types.QueryChildrenRequest{}
+	}
+
+	return q.client.createQuery(ctx, q.path("answers", strconv.Itoa(index), child), request)
+}
+
+// Delete removes the query from Orthanc
+// This endpoint implements the DELETE /queries/{id} request
+func (q *QueryHandle) Delete(ctx context.Context) error {
+	return q.client.delete(ctx, q.path(), nil)
+}
+
+// buildQueryAnswersQuery builds the query string selecting the format of query answers
+func buildQueryAnswersQuery(expand bool, opts *types.QueryAnswersOptions) string {
+	var query queryBuilder
+
+	if expand {
+		query.addFlag("expand")
+	}
+
+	if opts != nil {
+		if opts.Simplify {
+			query.addFlag("simplify")
+		}
+		if opts.Short {
+			query.addFlag("short")
+		}
+	}
+
+	return query.encode()
+}
@@ -0,0 +1,190 @@
+package gorthanc
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+)
+
+// RetryPolicy configures how failed requests are retried
+//
+// Requests are retried on transport errors and on 429, 502, 503 and 504 responses.
+// A request whose body cannot be replayed is never retried. Bodies built by the
+// client are always replayable, readers passed by the caller (e.g. to UploadDicomFile)
+// are replayable when they implement io.Seeker.
+type RetryPolicy struct {
+	// Maximum number of retries after the first attempt
+	MaxRetries int
+
+	// Backoff before the first retry, doubled on each further retry (default: 100ms)
+	InitialBackoff time.Duration
+
+	// Upper bound for the backoff between two attempts (default: 5s)
+	MaxBackoff time.Duration
+
+	// Also retry non-idempotent requests such as POST (default: false)
+	RetryNonIdempotent bool
+}
+
+// WithRetryPolicy enables retries with jittered exponential backoff
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// isIdempotentMethod reports whether a request with the given method can safely be sent twice
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether a response status is worth retrying
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// shouldRetry decides whether the outcome of an attempt should be retried
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotentMethod(req.Method) {
+		return false
+	}
+
+	if !isReplayable(req) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return isRetryableStatus(resp.StatusCode)
+}
+
+// backoff computes the delay before the given retry attempt (starting at 0)
+// A Retry-After header expressed in seconds takes precedence when present.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxBackoff)
+		}
+	}
+
+	delay := initial << attempt
+	if delay <= 0 || delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	// Equal jitter: wait between half and the full computed delay
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// makeReplayable sets GetBody on requests whose body is an io.Seeker,
+// so that they can be rewound before a retry
+func makeReplayable(req *http.Request, body io.Reader) {
+	if req.GetBody != nil || body == nil {
+		return
+	}
+
+	seeker, ok := body.(io.Seeker)
+	if !ok {
+		return
+	}
+
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		// Not actually seekable (e.g. a pipe), leave the request non-replayable
+		return
+	}
+
+	// Keep the transport from closing the caller's reader, it must stay usable for a retry
+	req.Body = io.NopCloser(body)
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		return io.NopCloser(body), nil
+	}
+}
+
+// isReplayable reports whether a request can be sent again
+func isReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// cloneForRetry copies a request with a fresh body so that it can be sent again
+func cloneForRetry(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
+
+// send performs the request, retrying it according to the client retry policy
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy
+	if policy == nil {
+		return c.roundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.roundTrip(req)
+		if attempt >= policy.MaxRetries || !policy.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		next, err := cloneForRetry(req)
+		if err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
@@ -1,14 +1,14 @@
 package gorthanc
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"strconv"
 
 	"github.com/proencaj/gorthanc/types"
 )
 
-func (c *Client) GetSeries(params *types.SeriesQueryParams) ([]string, error) {
+func (c *Client) GetSeries(ctx context.Context, params *types.SeriesQueryParams) ([]string, error) {
 	path := "series"
 
 	if params != nil {
@@ -21,7 +21,7 @@ func (c *Client) GetSeries(params *types.SeriesQueryParams) ([]string, error) {
 	}
 
 	var seriesIDs []string
-	if err := c.get(path, &seriesIDs); err != nil {
+	if err := c.get(ctx, path, &seriesIDs); err != nil {
 		return nil, err
 	}
 
@@ -29,18 +29,17 @@ func (c *Client) GetSeries(params *types.SeriesQueryParams) ([]string, error) {
 }
 
 
-func (c *Client) GetSeriesExpanded(params *types.SeriesQueryParams) ([]types.Series, error) {
-	// Ensure expand is set
-	if params == nil {
-		params = &types.SeriesQueryParams{Expand: true}
-	} else {
-		params.Expand = true
+func (c *Client) GetSeriesExpanded(ctx context.Context, params *types.SeriesQueryParams) ([]types.Series, error) {
+	query := types.SeriesQueryParams{}
+	if params != nil {
+		query = *params
 	}
+	query.Expand = true
 
-	path := c.buildSeriesPath("series", params)
+	path := c.buildSeriesPath("series", &query)
 
 	var seriesList []types.Series
-	if err := c.get(path, &seriesList); err != nil {
+	if err := c.get(ctx, path, &seriesList); err != nil {
 		return nil, err
 	}
 
@@ -48,11 +47,11 @@ func (c *Client) GetSeriesExpanded(params *types.SeriesQueryParams) ([]types.Ser
 }
 
 
-func (c *Client) GetSeriesDetail(seriesID string) (*types.Series, error) {
+func (c *Client) GetSeriesDetail(ctx context.Context, seriesID string) (*types.Series, error) {
 	var series types.Series
-	path := fmt.Sprintf("series/%s", seriesID)
+	path := endpointPath("series", seriesID)
 
-	if err := c.get(path, &series); err != nil {
+	if err := c.get(ctx, path, &series); err != nil {
 		return nil, err
 	}
 
@@ -60,37 +59,96 @@ func (c *Client) GetSeriesDetail(seriesID string) (*types.Series, error) {
 }
 
 
-func (c *Client) DeleteSeries(seriesID string) error {
-	path := fmt.Sprintf("series/%s", seriesID)
-	return c.delete(path, nil) 
+func (c *Client) DeleteSeries(ctx context.Context, seriesID string) error {
+	path := endpointPath("series", seriesID)
+	return c.delete(ctx, path, nil) 
 }
 
 
-// Only implemented the synchronous version, asynchronous version will be available later
-func (c *Client) AnonymizeSeries(seriesID string, anonymizeRequest *types.SeriesAnonymizeRequest) (*types.SeriesAnonymizeResponse, error) {
+// AnonymizeSeries anonymizes a series synchronously
+// Large series may exceed the HTTP client timeout, use AnonymizeSeriesAsync for those.
+func (c *Client) AnonymizeSeries(ctx context.Context, seriesID string, anonymizeRequest *types.SeriesAnonymizeRequest) (*types.SeriesAnonymizeResponse, error) {
 	var result types.SeriesAnonymizeResponse
-	path := fmt.Sprintf("series/%s/anonymize", seriesID)
-	anonymizeRequest.Asynchronous = BoolPtr(false)
+	path := endpointPath("series", seriesID, "anonymize")
+	request := types.SeriesAnonymizeRequest{}
+	if anonymizeRequest != nil {
+		request = *anonymizeRequest
+	}
+	request.Asynchronous = BoolPtr(false)
+
+	if err := c.post(ctx, path, &request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+
+// AnonymizeSeriesAsync submits the anonymization of a series as an Orthanc job
+// Use the returned handle to wait for the job, its Content holds the ID of the new series.
+func (c *Client) AnonymizeSeriesAsync(ctx context.Context, seriesID string, anonymizeRequest *types.SeriesAnonymizeRequest) (*JobHandle, error) {
+	path := endpointPath("series", seriesID, "anonymize")
+	request := types.SeriesAnonymizeRequest{}
+	if anonymizeRequest != nil {
+		request = *anonymizeRequest
+	}
+	request.Asynchronous = BoolPtr(true)
+
+	return c.submitJob(ctx, path, &request)
+}
+
+
+// ModifySeries modifies the DICOM tags of a series synchronously
+// Orthanc creates a new series, the source is kept unless KeepSource is set to false.
+func (c *Client) ModifySeries(ctx context.Context, seriesID string, modifyRequest *types.ModifyRequest) (*types.ModifyResponse, error) {
+	var result types.ModifyResponse
+	path := endpointPath("series", seriesID, "modify")
+	request := types.ModifyRequest{}
+	if modifyRequest != nil {
+		request = *modifyRequest
+	}
+	request.Asynchronous = BoolPtr(false)
 
-	if err := c.post(path, anonymizeRequest, &result); err != nil {
+	if err := c.post(ctx, path, &request, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
+// ModifySeriesAsync submits the modification of a series as an Orthanc job
+func (c *Client) ModifySeriesAsync(ctx context.Context, seriesID string, modifyRequest *types.ModifyRequest) (*JobHandle, error) {
+	path := endpointPath("series", seriesID, "modify")
+	request := types.ModifyRequest{}
+	if modifyRequest != nil {
+		request = *modifyRequest
+	}
+	request.Asynchronous = BoolPtr(true)
 
-func (c *Client) DownloadSeriesArchive(seriesID string) (*http.Response, error) {
-	path := fmt.Sprintf("series/%s/archive", seriesID)
-	return c.getWithRawResponse(path)
+	return c.submitJob(ctx, path, &request)
 }
 
 
-func (c *Client) GetSeriesStatistics(seriesID string) (*types.Statistics, error) {
+// DownloadSeriesArchive downloads a ZIP archive of the series.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadSeriesArchive(ctx context.Context, seriesID string) (*http.Response, error) {
+	path := endpointPath("series", seriesID, "archive")
+	return c.getWithRawResponse(ctx, path)
+}
+
+// DownloadSeriesMedia downloads a ZIP archive of the series containing a DICOMDIR.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadSeriesMedia(ctx context.Context, seriesID string) (*http.Response, error) {
+	path := endpointPath("series", seriesID, "media")
+	return c.getWithRawResponse(ctx, path)
+}
+
+
+func (c *Client) GetSeriesStatistics(ctx context.Context, seriesID string) (*types.Statistics, error) {
 	var stats types.Statistics
-	path := fmt.Sprintf("series/%s/statistics", seriesID)
+	path := endpointPath("series", seriesID, "statistics")
 
-	if err := c.get(path, &stats); err != nil {
+	if err := c.get(ctx, path, &stats); err != nil {
 		return nil, err
 	}
 
@@ -98,22 +156,22 @@ func (c *Client) GetSeriesStatistics(seriesID string) (*types.Statistics, error)
 }
 
 
-func (c *Client) GetSeriesInstances(seriesID string) ([]string, error) {
+func (c *Client) GetSeriesInstances(ctx context.Context, seriesID string) ([]string, error) {
 	var instanceIDs []string
-	path := fmt.Sprintf("series/%s/instances?expand=false", seriesID)
+	path := endpointPath("series", seriesID, "instances") + "?expand=false"
 
-	if err := c.get(path, &instanceIDs); err != nil {
+	if err := c.get(ctx, path, &instanceIDs); err != nil {
 		return nil, err
 	}
 
 	return instanceIDs, nil
 }
 
-func (c *Client) GetSeriesInstancesExpanded(seriesID string) ([]types.Instance, error) {
+func (c *Client) GetSeriesInstancesExpanded(ctx context.Context, seriesID string) ([]types.Instance, error) {
 	var instances []types.Instance
-	path := fmt.Sprintf("series/%s/instances?expand=true", seriesID)
+	path := endpointPath("series", seriesID, "instances") + "?expand=true"
 
-	if err := c.get(path, &instances); err != nil {
+	if err := c.get(ctx, path, &instances); err != nil {
 		return nil, err
 	}
 
@@ -128,23 +186,13 @@ func (c *Client) buildSeriesPath(basePath string, params *types.SeriesQueryParam
 		return basePath
 	}
 
-	// Build query string manually
-	queryParams := ""
-	separator := "?"
+	var query queryBuilder
 
 	if params.Expand {
-		queryParams += separator + "expand"
-		separator = "&"
+		query.addFlag("expand")
 	}
 
-	if params.Since >= 0 {
-		queryParams += separator + "since=" + strconv.Itoa(params.Since)
-		separator = "&"
-	}
-
-	if params.Limit > 0 {
-		queryParams += separator + "limit=" + strconv.Itoa(params.Limit)
-	}
+	query.addPage(params.Since, params.Limit)
 
-	return basePath + queryParams
+	return basePath + query.encode()
 }
@@ -0,0 +1,106 @@
+package gorthanc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+const (
+	defaultCommitmentPollInterval    = 500 * time.Millisecond
+	defaultCommitmentMaxPollInterval = 10 * time.Second
+)
+
+// WaitForStorageCommitmentOptions configures how WaitForStorageCommitment polls a transaction
+type WaitForStorageCommitmentOptions struct {
+	// Delay before the first poll (default: 500ms)
+	InitialInterval time.Duration
+
+	// Upper bound for the delay between two polls (default: 10s)
+	MaxInterval time.Duration
+}
+
+// GetStorageCommitmentReport retrieves the report of a storage commitment transaction
+// This endpoint implements the GET /storage-commitment/{id} request
+func (c *Client) GetStorageCommitmentReport(ctx context.Context, transactionUID string) (*types.StorageCommitmentReport, error) {
+	var report types.StorageCommitmentReport
+	if err := c.get(ctx, endpointPath("storage-commitment", transactionUID), &report); err != nil {
+		return nil, err
+	}
+	report.TransactionUID = transactionUID
+	return &report, nil
+}
+
+// RemoveCommittedInstances deletes from Orthanc the instances committed by a successful transaction
+// Orthanc refuses to remove the instances unless the status of the transaction is Success.
+// This endpoint implements the POST /storage-commitment/{id}/remove request
+func (c *Client) RemoveCommittedInstances(ctx context.Context, transactionUID string) error {
+	return c.post(ctx, endpointPath("storage-commitment", transactionUID, "remove"), nil, nil)
+}
+
+// WaitForStorageCommitment polls a transaction until the N-EVENT-REPORT of the modality is received
+// The delay between two polls doubles after each attempt, up to opts.MaxInterval.
+// A modality may never send the report, so ctx should carry a deadline.
+func (c *Client) WaitForStorageCommitment(ctx context.Context, transactionUID string, opts *WaitForStorageCommitmentOptions) (*types.StorageCommitmentReport, error) {
+	interval := defaultCommitmentPollInterval
+	maxInterval := defaultCommitmentMaxPollInterval
+	if opts != nil {
+		if opts.InitialInterval > 0 {
+			interval = opts.InitialInterval
+		}
+		if opts.MaxInterval > 0 {
+			maxInterval = opts.MaxInterval
+		}
+	}
+
+	for {
+		report, err := c.GetStorageCommitmentReport(ctx, transactionUID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !report.IsPending() {
+			return report, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// StoreToModalityWithCommitment sends resources to a modality and waits for its storage commitment report
+// The transfer is synchronous, then the transaction is polled as in WaitForStorageCommitment.
+// The report lists the committed and failed instances, use RemoveCommittedInstances to delete
+// the local copies once the status is Success.
+func (c *Client) StoreToModalityWithCommitment(ctx context.Context, modalityName string, request *types.ModalityStoreRequest, opts *WaitForStorageCommitmentOptions) (*types.StorageCommitmentReport, error) {
+	if request == nil {
+		return nil, fmt.Errorf("ModalityStoreRequest is required")
+	}
+
+	storeRequest := *request
+	storeRequest.Synchronous = BoolPtr(true)
+	storeRequest.StorageCommitment = BoolPtr(true)
+
+	result, err := c.StoreToModalityWithOptions(ctx, modalityName, &storeRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.StorageCommitmentTransactionUID == "" {
+		return nil, fmt.Errorf("failed to get storage commitment transaction from response")
+	}
+
+	return c.WaitForStorageCommitment(ctx, result.StorageCommitmentTransactionUID, opts)
+}
@@ -1,17 +1,17 @@
 package gorthanc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/proencaj/gorthanc/types"
 )
 
-func (c *Client) GetStudies(params *types.StudiesQueryParams) ([]string, error) {
+func (c *Client) GetStudies(ctx context.Context, params *types.StudiesQueryParams) ([]string, error) {
 	path := "studies"
 	
 	if params != nil {
@@ -24,7 +24,7 @@ func (c *Client) GetStudies(params *types.StudiesQueryParams) ([]string, error)
 	}
 
 	var studyIDs []string
-	if err := c.get(path, &studyIDs); err != nil {
+	if err := c.get(ctx, path, &studyIDs); err != nil {
 		return nil, err
 	}
 
@@ -32,18 +32,17 @@ func (c *Client) GetStudies(params *types.StudiesQueryParams) ([]string, error)
 }
 
 
-func (c *Client) GetStudiesExpanded(params *types.StudiesQueryParams) ([]types.Study, error) {
-	// Ensure expand is set
-	if params == nil {
-		params = &types.StudiesQueryParams{Expand: true}
-	} else {
-		params.Expand = true
+func (c *Client) GetStudiesExpanded(ctx context.Context, params *types.StudiesQueryParams) ([]types.Study, error) {
+	query := types.StudiesQueryParams{}
+	if params != nil {
+		query = *params
 	}
+	query.Expand = true
 
-	path := c.buildStudiesPath("studies", params)
+	path := c.buildStudiesPath("studies", &query)
 
 	var studies []types.Study
-	if err := c.get(path, &studies); err != nil {
+	if err := c.get(ctx, path, &studies); err != nil {
 		return nil, err
 	}
 
@@ -51,11 +50,11 @@ func (c *Client) GetStudiesExpanded(params *types.StudiesQueryParams) ([]types.S
 }
 
 
-func (c *Client) GetStudy(studyID string) (*types.Study, error) {
+func (c *Client) GetStudy(ctx context.Context, studyID string) (*types.Study, error) {
 	var study types.Study
-	path := fmt.Sprintf("studies/%s", studyID)
+	path := endpointPath("studies", studyID)
 
-	if err := c.get(path, &study); err != nil {
+	if err := c.get(ctx, path, &study); err != nil {
 		return nil, err
 	}
 
@@ -68,70 +67,122 @@ func (c *Client) buildStudiesPath(basePath string, params *types.StudiesQueryPar
 		return basePath
 	}
 
-	// Build query string manually
-	queryParams := ""
-	separator := "?"
+	var query queryBuilder
 
 	if params.Expand {
-		queryParams += separator + "expand"
-		separator = "&"
+		query.addFlag("expand")
 	}
 
-	if params.Since >= 0 {
-		queryParams += separator + "since=" + strconv.Itoa(params.Since)
-		separator = "&"
-	}
-
-	if params.Limit > 0 {
-		queryParams += separator + "limit=" + strconv.Itoa(params.Limit)
-	}
+	query.addPage(params.Since, params.Limit)
 
 	if params.Short {
-		queryParams += separator + "short"
-		separator = "&"
+		query.addFlag("short")
 	}
 
 	if params.Full {
-		queryParams += separator + "full"
-		separator = "&"
+		query.addFlag("full")
 	}
 
-	return basePath + queryParams
+	return basePath + query.encode()
 }
 
-func (c *Client) DeleteStudy(studyID string) error { 
-	path := fmt.Sprintf("studies/%s", studyID)
-	return c.delete(path, nil) 
+func (c *Client) DeleteStudy(ctx context.Context, studyID string) error { 
+	path := endpointPath("studies", studyID)
+	return c.delete(ctx, path, nil) 
 }
 
-// Only implemented the synchronous version, asynchronous version will be available later
-func (c *Client) AnonymizeStudy(studyID string, anonymizeRequest *types.StudyAnonymizeRequest) (*types.StudyAnonymizeResponse, error) {
+// AnonymizeStudy anonymizes a study synchronously
+// Large studies may exceed the HTTP client timeout, use AnonymizeStudyAsync for those.
+func (c *Client) AnonymizeStudy(ctx context.Context, studyID string, anonymizeRequest *types.StudyAnonymizeRequest) (*types.StudyAnonymizeResponse, error) {
 	var result types.StudyAnonymizeResponse
-	path := fmt.Sprintf("studies/%s/anonymize", studyID)
-	anonymizeRequest.Asynchronous = BoolPtr(false)
+	path := endpointPath("studies", studyID, "anonymize")
+	request := types.StudyAnonymizeRequest{}
+	if anonymizeRequest != nil {
+		request = *anonymizeRequest
+	}
+	request.Asynchronous = BoolPtr(false)
 
-	if err := c.post(path, anonymizeRequest, &result); err != nil {
+	if err := c.post(ctx, path, &request, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
+// AnonymizeStudyAsync submits the anonymization of a study as an Orthanc job
+// Use the returned handle to wait for the job, its Content holds the ID of the new study.
+func (c *Client) AnonymizeStudyAsync(ctx context.Context, studyID string, anonymizeRequest *types.StudyAnonymizeRequest) (*JobHandle, error) {
+	path := endpointPath("studies", studyID, "anonymize")
+	request := types.StudyAnonymizeRequest{}
+	if anonymizeRequest != nil {
+		request = *anonymizeRequest
+	}
+	request.Asynchronous = BoolPtr(true)
+
+	return c.submitJob(ctx, path, &request)
+}
+
+// ModifyStudy modifies the DICOM tags of a study synchronously
+// Orthanc creates a new study, the source is kept unless KeepSource is set to false.
+func (c *Client) ModifyStudy(ctx context.Context, studyID string, modifyRequest *types.ModifyRequest) (*types.ModifyResponse, error) {
+	var result types.ModifyResponse
+	path := endpointPath("studies", studyID, "modify")
+	request := types.ModifyRequest{}
+	if modifyRequest != nil {
+		request = *modifyRequest
+	}
+	request.Asynchronous = BoolPtr(false)
+
+	if err := c.post(ctx, path, &request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ModifyStudyAsync submits the modification of a study as an Orthanc job
+func (c *Client) ModifyStudyAsync(ctx context.Context, studyID string, modifyRequest *types.ModifyRequest) (*JobHandle, error) {
+	path := endpointPath("studies", studyID, "modify")
+	request := types.ModifyRequest{}
+	if modifyRequest != nil {
+		request = *modifyRequest
+	}
+	request.Asynchronous = BoolPtr(true)
+
+	return c.submitJob(ctx, path, &request)
+}
+
 // getWithRawResponse performs a GET request and returns the raw response
-func (c *Client) getWithRawResponse(path string) (*http.Response, error) {
-	return c.doRequest(http.MethodGet, path, nil)
+func (c *Client) getWithRawResponse(ctx context.Context, path string) (*http.Response, error) {
+	return c.doRequest(ctx, http.MethodGet, path, nil)
 }
 
 // getWithAcceptRawResponse performs a GET request with a custom Accept header and returns the raw response
-func (c *Client) getWithAcceptRawResponse(path string, accept string) (*http.Response, error) {
-	return c.doRequestWithAccept(http.MethodGet, path, nil, accept)
+func (c *Client) getWithAcceptRawResponse(ctx context.Context, path string, accept string) (*http.Response, error) {
+	return c.doRequestWithAccept(ctx, http.MethodGet, path, nil, accept)
+}
+
+// getPlainText performs a GET request and returns the plain text response
+func (c *Client) getPlainText(ctx context.Context, path string) (string, error) {
+	resp, err := c.doRequestWithAccept(ctx, http.MethodGet, path, nil, "text/plain")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return strings.TrimSpace(string(body)), nil
 }
 
 // putWithPlainText performs a PUT request with a plain text body
-func (c *Client) putWithPlainText(path string, body string) error {
+func (c *Client) putWithPlainText(ctx context.Context, path string, body string) error {
 	bodyReader := strings.NewReader(body)
 
-	resp, err := c.doRequest(http.MethodPut, path, bodyReader)
+	resp, err := c.doRequest(ctx, http.MethodPut, path, bodyReader)
 	if err != nil {
 		return err
 	}
@@ -141,7 +192,7 @@ func (c *Client) putWithPlainText(path string, body string) error {
 }
 
 // postWithRawResponse performs a GET request and returns the raw response
-func (c *Client) postWithBodyAndRawResponse(path string, body interface{}) (*http.Response, error) {
+func (c *Client) postWithBodyAndRawResponse(ctx context.Context, path string, body interface{}) (*http.Response, error) {
 	var bodyReader io.Reader
 
 	if body != nil {
@@ -149,63 +200,72 @@ func (c *Client) postWithBodyAndRawResponse(path string, body interface{}) (*htt
 		bodyReader = strings.NewReader(string(bodyBytes))
 	}
 
-	return c.doRequest(http.MethodPost, path, bodyReader)
+	return c.doRequest(ctx, http.MethodPost, path, bodyReader)
+}
+
+// DownloadStudyArchive downloads a ZIP archive of the study.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadStudyArchive(ctx context.Context, studyID string) (*http.Response, error) {
+	path := endpointPath("studies", studyID, "archive")
+	return c.getWithRawResponse(ctx, path)
 }
 
-func (c *Client) DownloadStudyArchive(studyID string) (*http.Response, error) {
-	path := fmt.Sprintf("studies/%s/archive", studyID)
-	return c.getWithRawResponse(path)
+// DownloadStudyMedia downloads a ZIP archive of the study containing a DICOMDIR.
+// The caller is responsible for closing the response body.
+func (c *Client) DownloadStudyMedia(ctx context.Context, studyID string) (*http.Response, error) {
+	path := endpointPath("studies", studyID, "media")
+	return c.getWithRawResponse(ctx, path)
 }
 
-func (c *Client) GetStudyStatistics(studyID string) (*types.Statistics, error) {
+func (c *Client) GetStudyStatistics(ctx context.Context, studyID string) (*types.Statistics, error) {
 	var stats types.Statistics
-	path := fmt.Sprintf("studies/%s/statistics", studyID)
+	path := endpointPath("studies", studyID, "statistics")
 
-	if err := c.get(path, &stats); err != nil {
+	if err := c.get(ctx, path, &stats); err != nil {
 		return nil, err
 	}
 
 	return &stats, nil
 }
 
-func (c *Client) GetStudySeries(studyID string) ([]string, error) {
+func (c *Client) GetStudySeries(ctx context.Context, studyID string) ([]string, error) {
 	var seriesIDs []string
-	path := fmt.Sprintf("studies/%s/series?expand=false", studyID)
+	path := endpointPath("studies", studyID, "series") + "?expand=false"
 
-	if err := c.get(path, &seriesIDs); err != nil {
+	if err := c.get(ctx, path, &seriesIDs); err != nil {
 		return nil, err
 	}
 
 	return seriesIDs, nil
 }
 
-func (c *Client) GetStudySeriesExpanded(studyID string) ([]types.Series, error) {
+func (c *Client) GetStudySeriesExpanded(ctx context.Context, studyID string) ([]types.Series, error) {
 	var series []types.Series
-	path := fmt.Sprintf("studies/%s/series?expand=true", studyID)
+	path := endpointPath("studies", studyID, "series") + "?expand=true"
 
-	if err := c.get(path, &series); err != nil {
+	if err := c.get(ctx, path, &series); err != nil {
 		return nil, err
 	}
 
 	return series, nil
 }
 
-func (c *Client) GetStudyInstances(studyID string) ([]string, error) {
+func (c *Client) GetStudyInstances(ctx context.Context, studyID string) ([]string, error) {
 	var instanceIDs []string
-	path := fmt.Sprintf("studies/%s/instances?expand=false", studyID)
+	path := endpointPath("studies", studyID, "instances") + "?expand=false"
 
-	if err := c.get(path, &instanceIDs); err != nil {
+	if err := c.get(ctx, path, &instanceIDs); err != nil {
 		return nil, err
 	}
 
 	return instanceIDs, nil
 }
 
-func (c *Client) GetStudyInstancesExpanded(studyID string) ([]types.Instance, error) {
+func (c *Client) GetStudyInstancesExpanded(ctx context.Context, studyID string) ([]types.Instance, error) {
 	var instances []types.Instance
-	path := fmt.Sprintf("studies/%s/instances?expand=true", studyID)
+	path := endpointPath("studies", studyID, "instances") + "?expand=true"
 
-	if err := c.get(path, &instances); err != nil {
+	if err := c.get(ctx, path, &instances); err != nil {
 		return nil, err
 	}
 
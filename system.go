@@ -1,18 +1,22 @@
 package gorthanc
 
-import "github.com/proencaj/gorthanc/types"
+import (
+	"context"
 
-func (c *Client) GetSystem() (*types.SystemInfo, error) {
+	"github.com/proencaj/gorthanc/types"
+)
+
+func (c *Client) GetSystem(ctx context.Context) (*types.SystemInfo, error) {
 	var info types.SystemInfo
-	if err := c.get("system", &info); err != nil {
+	if err := c.get(ctx, "system", &info); err != nil {
 		return nil, err
 	}
 	return &info, nil
 }
 
-func (c *Client) GetSystemStatistics() (*types.SystemStatistics, error) {
+func (c *Client) GetSystemStatistics(ctx context.Context) (*types.SystemStatistics, error) {
 	var statistics types.SystemStatistics
-	if err := c.get("statistics", &statistics); err != nil {
+	if err := c.get(ctx, "statistics", &statistics); err != nil {
 		return nil, err
 	}
 	return &statistics, nil
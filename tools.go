@@ -1,6 +1,7 @@
 package gorthanc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,13 +13,13 @@ import (
 // Find searches for DICOM resources in the local Orthanc database
 // This endpoint implements the /tools/find POST request
 // Returns a list of resource IDs (when Expand is false or nil)
-func (c *Client) Find(request *types.ToolsFindRequest) ([]string, error) {
+func (c *Client) Find(ctx context.Context, request *types.ToolsFindRequest) ([]string, error) {
 	if request.Expand != nil && *request.Expand {
 		return nil, fmt.Errorf("use FindExpanded() when Expand is true")
 	}
 
 	var result []string
-	if err := c.post("tools/find", request, &result); err != nil {
+	if err := c.post(ctx, "tools/find", request, &result); err != nil {
 		return nil, err
 	}
 
@@ -28,13 +29,13 @@ func (c *Client) Find(request *types.ToolsFindRequest) ([]string, error) {
 // FindExpanded searches for DICOM resources with expanded details
 // This endpoint implements the /tools/find POST request with Expand=true
 // Returns a list of expanded resource objects
-func (c *Client) FindExpanded(request *types.ToolsFindRequest) ([]types.ToolsFindExpandedResource, error) {
+func (c *Client) FindExpanded(ctx context.Context, request *types.ToolsFindRequest) ([]types.ToolsFindExpandedResource, error) {
 	// Ensure Expand is set to true
 	expand := true
 	request.Expand = &expand
 
 	var rawResult []json.RawMessage
-	if err := c.post("tools/find", request, &rawResult); err != nil {
+	if err := c.post(ctx, "tools/find", request, &rawResult); err != nil {
 		return nil, err
 	}
 
@@ -51,24 +52,35 @@ func (c *Client) FindExpanded(request *types.ToolsFindRequest) ([]types.ToolsFin
 	return result, nil
 }
 
+// DicomEcho checks the connectivity with a modality that is not registered in Orthanc
+// This allows testing the parameters of a modality before calling CreateOrUpdateModality.
+// As with EchoModality, a failed echo is reported in the result rather than as an error.
+// This endpoint implements the /tools/dicom-echo POST request
+func (c *Client) DicomEcho(ctx context.Context, request *types.DicomEchoRequest) (*types.ModalityEchoResult, error) {
+	if request == nil {
+		return nil, fmt.Errorf("DicomEchoRequest is required")
+	}
+	return c.echo(ctx, "tools/dicom-echo", request)
+}
+
 // Reset performs a hot restart of Orthanc
 // This endpoint implements the /tools/reset POST request
 // The configuration file will be read again
-func (c *Client) Reset() error {
-	return c.post("tools/reset", nil, nil)
+func (c *Client) Reset(ctx context.Context) error {
+	return c.post(ctx, "tools/reset", nil, nil)
 }
 
 // Shutdown shuts down Orthanc
 // This endpoint implements the /tools/shutdown POST request
-func (c *Client) Shutdown() error {
-	return c.post("tools/shutdown", nil, nil)
+func (c *Client) Shutdown(ctx context.Context) error {
+	return c.post(ctx, "tools/shutdown", nil, nil)
 }
 
 // GetLogLevel retrieves the current log level
 // This endpoint implements the GET /tools/log-level request
 // Returns one of: "default", "verbose", or "trace"
-func (c *Client) GetLogLevel() (types.LogLevel, error) {
-	resp, err := c.getWithRawResponse("tools/log-level")
+func (c *Client) GetLogLevel(ctx context.Context) (types.LogLevel, error) {
+	resp, err := c.getWithRawResponse(ctx, "tools/log-level")
 	if err != nil {
 		return "", err
 	}
@@ -87,6 +99,6 @@ func (c *Client) GetLogLevel() (types.LogLevel, error) {
 // This endpoint implements the PUT /tools/log-level request
 // Valid levels: LogLevelDefault, LogLevelVerbose, LogLevelTrace
 // Note: This resets all category-specific log levels
-func (c *Client) SetLogLevel(level types.LogLevel) error {
-	return c.putWithPlainText("tools/log-level", string(level))
+func (c *Client) SetLogLevel(ctx context.Context, level types.LogLevel) error {
+	return c.putWithPlainText(ctx, "tools/log-level", string(level))
 }
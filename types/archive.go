@@ -0,0 +1,24 @@
+package types
+
+// ArchiveFormat represents the format of an archive generated by Orthanc
+type ArchiveFormat string
+
+const (
+	// ArchiveFormatZip is a ZIP archive organized by patient, study and series
+	ArchiveFormatZip ArchiveFormat = "archive"
+
+	// ArchiveFormatMedia is a ZIP archive with a DICOMDIR, suitable for DICOM media
+	ArchiveFormatMedia ArchiveFormat = "media"
+)
+
+// CreateArchiveOptions represents the options of /tools/create-archive and /tools/create-media
+type CreateArchiveOptions struct {
+	// Transcode the DICOM instances to the provided transfer syntax (optional)
+	Transcode string `json:"Transcode,omitempty"`
+
+	// Defines the priority of the job (only used in asynchronous mode)
+	Priority int `json:"Priority,omitempty"`
+
+	// Generate an extended DICOMDIR with additional tags (create-media only)
+	Extended *bool `json:"Extended,omitempty"`
+}
@@ -0,0 +1,106 @@
+package types
+
+// ChangeType represents the type of a change recorded by Orthanc
+type ChangeType string
+
+const (
+	ChangeTypeCompletedSeries   ChangeType = "CompletedSeries"
+	ChangeTypeDeleted           ChangeType = "Deleted"
+	ChangeTypeNewChildInstance  ChangeType = "NewChildInstance"
+	ChangeTypeNewInstance       ChangeType = "NewInstance"
+	ChangeTypeNewPatient        ChangeType = "NewPatient"
+	ChangeTypeNewSeries         ChangeType = "NewSeries"
+	ChangeTypeNewStudy          ChangeType = "NewStudy"
+	ChangeTypeStablePatient     ChangeType = "StablePatient"
+	ChangeTypeStableSeries      ChangeType = "StableSeries"
+	ChangeTypeStableStudy       ChangeType = "StableStudy"
+	ChangeTypeModifiedPatient   ChangeType = "ModifiedPatient"
+	ChangeTypeModifiedStudy     ChangeType = "ModifiedStudy"
+	ChangeTypeModifiedSeries    ChangeType = "ModifiedSeries"
+	ChangeTypeAnonymizedPatient ChangeType = "AnonymizedPatient"
+	ChangeTypeAnonymizedStudy   ChangeType = "AnonymizedStudy"
+	ChangeTypeAnonymizedSeries  ChangeType = "AnonymizedSeries"
+	ChangeTypeUpdatedAttachment ChangeType = "UpdatedAttachment"
+	ChangeTypeUpdatedMetadata   ChangeType = "UpdatedMetadata"
+)
+
+// Change represents a single entry of the Orthanc changes log
+type Change struct {
+	// Sequence number of the change, strictly increasing
+	Seq int64 `json:"Seq"`
+
+	// Type of the change
+	ChangeType ChangeType `json:"ChangeType"`
+
+	// Orthanc identifier of the resource the change relates to
+	ID string `json:"ID"`
+
+	// Path to access the resource
+	Path string `json:"Path"`
+
+	// Level of the resource (Patient, Study, Series, Instance)
+	ResourceType string `json:"ResourceType"`
+
+	// Timestamp of the change
+	Date string `json:"Date"`
+}
+
+// ChangesResponse represents the response from GET /changes
+type ChangesResponse struct {
+	// Changes returned by this call
+	Changes []Change `json:"Changes"`
+
+	// Whether the end of the changes log was reached
+	Done bool `json:"Done"`
+
+	// Sequence number of the last change returned, to be used as Since in the next call
+	Last int64 `json:"Last"`
+}
+
+// ChangesQueryParams represents query parameters for GET /changes and GET /exports
+type ChangesQueryParams struct {
+	// Return the changes following this sequence number
+	Since int64
+
+	// Maximum number of results to return (Orthanc defaults to 100)
+	Limit int
+}
+
+// Export represents a single entry of the Orthanc exports log
+type Export struct {
+	// Sequence number of the export, strictly increasing
+	Seq int64 `json:"Seq"`
+
+	// Orthanc identifier of the exported resource
+	ID string `json:"ID"`
+
+	// Path to access the resource
+	Path string `json:"Path"`
+
+	// Level of the resource (Patient, Study, Series, Instance)
+	ResourceType string `json:"ResourceType"`
+
+	// Name of the modality or peer the resource was sent to
+	RemoteModality string `json:"RemoteModality"`
+
+	// Timestamp of the export
+	Date string `json:"Date"`
+
+	// Main DICOM identifiers of the resource, depending on its level
+	PatientID         string `json:"PatientID,omitempty"`
+	StudyInstanceUID  string `json:"StudyInstanceUID,omitempty"`
+	SeriesInstanceUID string `json:"SeriesInstanceUID,omitempty"`
+	SOPInstanceUID    string `json:"SOPInstanceUID,omitempty"`
+}
+
+// ExportsResponse represents the response from GET /exports
+type ExportsResponse struct {
+	// Exports returned by this call
+	Exports []Export `json:"Exports"`
+
+	// Whether the end of the exports log was reached
+	Done bool `json:"Done"`
+
+	// Sequence number of the last export returned, to be used as Since in the next call
+	Last int64 `json:"Last"`
+}
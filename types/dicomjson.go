@@ -0,0 +1,323 @@
+package types
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DICOM tags of the attributes decoded into DicomWebStudy, DicomWebSeries and DicomWebInstance
+const (
+	TagSOPClassUID                     = "00080016"
+	TagSOPInstanceUID                  = "00080018"
+	TagStudyDate                       = "00080020"
+	TagStudyTime                       = "00080030"
+	TagAccessionNumber                 = "00080050"
+	TagModality                        = "00080060"
+	TagModalitiesInStudy               = "00080061"
+	TagReferringPhysicianName          = "00080090"
+	TagStudyDescription                = "00081030"
+	TagSeriesDescription               = "0008103E"
+	TagRetrieveURL                     = "00081190"
+	TagPatientName                     = "00100010"
+	TagPatientID                       = "00100020"
+	TagPatientBirthDate                = "00100030"
+	TagPatientSex                      = "00100040"
+	TagStudyInstanceUID                = "0020000D"
+	TagSeriesInstanceUID               = "0020000E"
+	TagStudyID                         = "00200010"
+	TagSeriesNumber                    = "00200011"
+	TagInstanceNumber                  = "00200013"
+	TagNumberOfStudyRelatedSeries      = "00201206"
+	TagNumberOfStudyRelatedInstances   = "00201208"
+	TagNumberOfSeriesRelatedInstances  = "00201209"
+	TagNumberOfFrames                  = "00280008"
+	TagRows                            = "00280010"
+	TagColumns                         = "00280011"
+	TagBitsAllocated                   = "00280100"
+	TagPerformedProcedureStepStartDate = "00400244"
+	TagPerformedProcedureStepStartTime = "00400245"
+)
+
+// DicomJSONAttribute represents a single attribute of the DICOM JSON model (PS3.18 Annex F)
+type DicomJSONAttribute struct {
+	// Value Representation (e.g., "UI", "PN", "IS", "SQ")
+	VR string `json:"vr"`
+
+	// Values of the attribute, decoded according to the VR by the DicomJSONDataset accessors
+	Value []json.RawMessage `json:"Value,omitempty"`
+
+	// URI from which the binary value can be retrieved (bulk data)
+	BulkDataURI string `json:"BulkDataURI,omitempty"`
+
+	// Binary value encoded in base64
+	InlineBinary string `json:"InlineBinary,omitempty"`
+}
+
+// DicomJSONDataset represents a DICOM dataset in the DICOM JSON model, keyed by tag (e.g., "0020000D")
+// The accessors return the zero value when the attribute is missing or has an unexpected format.
+type DicomJSONDataset map[string]DicomJSONAttribute
+
+// PersonName represents a value of a PN attribute
+type PersonName struct {
+	// Alphabetic representation (e.g., "Doe^John")
+	Alphabetic string `json:"Alphabetic,omitempty"`
+
+	// Ideographic representation
+	Ideographic string `json:"Ideographic,omitempty"`
+
+	// Phonetic representation
+	Phonetic string `json:"Phonetic,omitempty"`
+}
+
+// String returns the alphabetic representation of the name
+func (p PersonName) String() string {
+	return p.Alphabetic
+}
+
+// attribute looks up an attribute, accepting lower case tags
+func (d DicomJSONDataset) attribute(tag string) (DicomJSONAttribute, bool) {
+	attr, ok := d[tag]
+	if !ok {
+		attr, ok = d[strings.ToUpper(tag)]
+	}
+	return attr, ok
+}
+
+// Has reports whether the dataset contains the attribute
+func (d DicomJSONDataset) Has(tag string) bool {
+	_, ok := d.attribute(tag)
+	return ok
+}
+
+// VR returns the Value Representation of an attribute
+func (d DicomJSONDataset) VR(tag string) string {
+	attr, _ := d.attribute(tag)
+	return attr.VR
+}
+
+// String returns the first value of an attribute as a string
+// Person names are returned in their alphabetic representation, numbers in their JSON representation.
+func (d DicomJSONDataset) String(tag string) string {
+	values := d.Strings(tag)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Strings returns all the values of an attribute as strings
+func (d DicomJSONDataset) Strings(tag string) []string {
+	attr, ok := d.attribute(tag)
+	if !ok || len(attr.Value) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(attr.Value))
+	for _, raw := range attr.Value {
+		values = append(values, rawString(attr.VR, raw))
+	}
+	return values
+}
+
+// Int returns the first value of a numeric attribute (IS, SS, US, SL, UL, ...) as an int
+func (d DicomJSONDataset) Int(tag string) int {
+	values := d.Ints(tag)
+	if len(values) == 0 {
+		return 0
+	}
+	return values[0]
+}
+
+// Ints returns all the values of a numeric attribute as ints
+// Values are parsed as integers, so that 64-bit values (SV, UV) keep their precision.
+func (d DicomJSONDataset) Ints(tag string) []int {
+	attr, ok := d.attribute(tag)
+	if !ok || len(attr.Value) == 0 {
+		return nil
+	}
+
+	values := make([]int, 0, len(attr.Value))
+	for _, raw := range attr.Value {
+		values = append(values, rawInt(raw))
+	}
+	return values
+}
+
+// Float returns the first value of a numeric attribute (DS, FL, FD, ...) as a float64
+func (d DicomJSONDataset) Float(tag string) float64 {
+	values := d.Floats(tag)
+	if len(values) == 0 {
+		return 0
+	}
+	return values[0]
+}
+
+// Floats returns all the values of a numeric attribute as float64
+// IS and DS values encoded as JSON strings are parsed as well.
+func (d DicomJSONDataset) Floats(tag string) []float64 {
+	attr, ok := d.attribute(tag)
+	if !ok || len(attr.Value) == 0 {
+		return nil
+	}
+
+	values := make([]float64, 0, len(attr.Value))
+	for _, raw := range attr.Value {
+		values = append(values, rawFloat(raw))
+	}
+	return values
+}
+
+// PersonName returns the first value of a PN attribute
+func (d DicomJSONDataset) PersonName(tag string) PersonName {
+	values := d.PersonNames(tag)
+	if len(values) == 0 {
+		return PersonName{}
+	}
+	return values[0]
+}
+
+// PersonNames returns all the values of a PN attribute
+func (d DicomJSONDataset) PersonNames(tag string) []PersonName {
+	attr, ok := d.attribute(tag)
+	if !ok || len(attr.Value) == 0 {
+		return nil
+	}
+
+	values := make([]PersonName, 0, len(attr.Value))
+	for _, raw := range attr.Value {
+		var name PersonName
+		if err := json.Unmarshal(raw, &name); err != nil {
+			// Some servers encode person names as plain strings
+			name.Alphabetic = rawString("", raw)
+		}
+		values = append(values, name)
+	}
+	return values
+}
+
+// Sequence returns the items of a SQ attribute
+func (d DicomJSONDataset) Sequence(tag string) []DicomJSONDataset {
+	attr, ok := d.attribute(tag)
+	if !ok || len(attr.Value) == 0 {
+		return nil
+	}
+
+	items := make([]DicomJSONDataset, 0, len(attr.Value))
+	for _, raw := range attr.Value {
+		var item DicomJSONDataset
+		if err := json.Unmarshal(raw, &item); err != nil {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// BulkDataURI returns the URI from which the value of a bulk data attribute can be retrieved
+func (d DicomJSONDataset) BulkDataURI(tag string) string {
+	attr, _ := d.attribute(tag)
+	return attr.BulkDataURI
+}
+
+// clone returns a copy of the dataset that can be modified, even when d is nil
+func (d DicomJSONDataset) clone() DicomJSONDataset {
+	clone := make(DicomJSONDataset, len(d))
+	maps.Copy(clone, d)
+	return clone
+}
+
+// set replaces the values of an attribute
+// The values are strings, numbers or person names, which always marshal.
+func (d DicomJSONDataset) set(tag, vr string, values ...any) {
+	raws := make([]json.RawMessage, 0, len(values))
+	for _, value := range values {
+		raw, _ := json.Marshal(value)
+		raws = append(raws, raw)
+	}
+
+	delete(d, strings.ToLower(tag))
+	d[strings.ToUpper(tag)] = DicomJSONAttribute{VR: vr, Value: raws}
+}
+
+// setString sets a single-valued attribute, unless value is empty or already the first value
+func (d DicomJSONDataset) setString(tag, vr, value string) {
+	if value != "" && value != d.String(tag) {
+		d.set(tag, vr, value)
+	}
+}
+
+// setStrings sets a multi-valued attribute, unless values is empty or unchanged
+func (d DicomJSONDataset) setStrings(tag, vr string, values []string) {
+	if len(values) == 0 || slices.Equal(values, d.Strings(tag)) {
+		return
+	}
+
+	anys := make([]any, len(values))
+	for i, value := range values {
+		anys[i] = value
+	}
+	d.set(tag, vr, anys...)
+}
+
+// setInt sets a numeric attribute, unless value is zero or already the first value
+func (d DicomJSONDataset) setInt(tag, vr string, value int) {
+	if value != 0 && value != d.Int(tag) {
+		d.set(tag, vr, value)
+	}
+}
+
+// setPersonName sets a PN attribute, unless name is empty or already the first value
+func (d DicomJSONDataset) setPersonName(tag string, name PersonName) {
+	if name != (PersonName{}) && name != d.PersonName(tag) {
+		d.set(tag, "PN", name)
+	}
+}
+
+// rawString converts a JSON value to a string according to the VR
+func rawString(vr string, raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	if vr == "PN" || (len(raw) > 0 && raw[0] == '{') {
+		var name PersonName
+		if err := json.Unmarshal(raw, &name); err == nil {
+			return name.Alphabetic
+		}
+	}
+
+	if string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+// rawInt converts a JSON number, or a string holding a number, to an int
+// Integers are parsed exactly, other numbers are truncated.
+func rawInt(raw json.RawMessage) int {
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		if i, err := strconv.ParseInt(strings.TrimSpace(n.String()), 10, 64); err == nil {
+			return int(i)
+		}
+	}
+	return int(rawFloat(raw))
+}
+
+// rawFloat converts a JSON number, or a string holding a number, to a float64
+func rawFloat(raw json.RawMessage) float64 {
+	var f float64
+	if err := json.Unmarshal(raw, &f); err == nil {
+		return f
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		f, _ = strconv.ParseFloat(strings.TrimSpace(s), 64)
+	}
+	return f
+}
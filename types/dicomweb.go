@@ -1,65 +1,222 @@
 package types
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // DicomWebStudy represents a study returned by QIDO-RS
 type DicomWebStudy struct {
 	// Study Instance UID (0020,000D)
-	StudyInstanceUID string `json:"0020000D,omitempty"`
+	StudyInstanceUID string
 	// Study Date (0008,0020)
-	StudyDate string `json:"00080020,omitempty"`
+	StudyDate string
 	// Study Time (0008,0030)
-	StudyTime string `json:"00080030,omitempty"`
+	StudyTime string
+	// Study Description (0008,1030)
+	StudyDescription string
 	// Accession Number (0008,0050)
-	AccessionNumber string `json:"00080050,omitempty"`
+	AccessionNumber string
 	// Modalities in Study (0008,0061)
-	ModalitiesInStudy string `json:"00080061,omitempty"`
+	ModalitiesInStudy []string
 	// Referring Physician's Name (0008,0090)
-	ReferringPhysicianName string `json:"00080090,omitempty"`
+	ReferringPhysicianName PersonName
 	// Patient's Name (0010,0010)
-	PatientName string `json:"00100010,omitempty"`
+	PatientName PersonName
 	// Patient ID (0010,0020)
-	PatientID string `json:"00100020,omitempty"`
+	PatientID string
+	// Patient's Birth Date (0010,0030)
+	PatientBirthDate string
+	// Patient's Sex (0010,0040)
+	PatientSex string
 	// Study ID (0020,0010)
-	StudyID string `json:"00200010,omitempty"`
+	StudyID string
 	// Number of Study Related Series (0020,1206)
-	NumberOfStudyRelatedSeries string `json:"00201206,omitempty"`
+	NumberOfStudyRelatedSeries int
 	// Number of Study Related Instances (0020,1208)
-	NumberOfStudyRelatedInstances string `json:"00201208,omitempty"`
+	NumberOfStudyRelatedInstances int
+	// Retrieve URL (0008,1190)
+	RetrieveURL string
+	// All the attributes of the result, including the ones requested with includefield
+	Dataset DicomJSONDataset
+}
+
+// MarshalJSON encodes the study in the DICOM JSON model
+// The typed fields take precedence, Dataset provides the other attributes.
+func (s DicomWebStudy) MarshalJSON() ([]byte, error) {
+	dataset := s.Dataset.clone()
+	dataset.setString(TagStudyInstanceUID, "UI", s.StudyInstanceUID)
+	dataset.setString(TagStudyDate, "DA", s.StudyDate)
+	dataset.setString(TagStudyTime, "TM", s.StudyTime)
+	dataset.setString(TagStudyDescription, "LO", s.StudyDescription)
+	dataset.setString(TagAccessionNumber, "SH", s.AccessionNumber)
+	dataset.setStrings(TagModalitiesInStudy, "CS", s.ModalitiesInStudy)
+	dataset.setPersonName(TagReferringPhysicianName, s.ReferringPhysicianName)
+	dataset.setPersonName(TagPatientName, s.PatientName)
+	dataset.setString(TagPatientID, "LO", s.PatientID)
+	dataset.setString(TagPatientBirthDate, "DA", s.PatientBirthDate)
+	dataset.setString(TagPatientSex, "CS", s.PatientSex)
+	dataset.setString(TagStudyID, "SH", s.StudyID)
+	dataset.setInt(TagNumberOfStudyRelatedSeries, "IS", s.NumberOfStudyRelatedSeries)
+	dataset.setInt(TagNumberOfStudyRelatedInstances, "IS", s.NumberOfStudyRelatedInstances)
+	dataset.setString(TagRetrieveURL, "UR", s.RetrieveURL)
+	return json.Marshal(dataset)
+}
+
+// UnmarshalJSON decodes a study from the DICOM JSON model
+func (s *DicomWebStudy) UnmarshalJSON(data []byte) error {
+	var dataset DicomJSONDataset
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return err
+	}
+
+	*s = DicomWebStudy{
+		StudyInstanceUID:              dataset.String(TagStudyInstanceUID),
+		StudyDate:                     dataset.String(TagStudyDate),
+		StudyTime:                     dataset.String(TagStudyTime),
+		StudyDescription:              dataset.String(TagStudyDescription),
+		AccessionNumber:               dataset.String(TagAccessionNumber),
+		ModalitiesInStudy:             dataset.Strings(TagModalitiesInStudy),
+		ReferringPhysicianName:        dataset.PersonName(TagReferringPhysicianName),
+		PatientName:                   dataset.PersonName(TagPatientName),
+		PatientID:                     dataset.String(TagPatientID),
+		PatientBirthDate:              dataset.String(TagPatientBirthDate),
+		PatientSex:                    dataset.String(TagPatientSex),
+		StudyID:                       dataset.String(TagStudyID),
+		NumberOfStudyRelatedSeries:    dataset.Int(TagNumberOfStudyRelatedSeries),
+		NumberOfStudyRelatedInstances: dataset.Int(TagNumberOfStudyRelatedInstances),
+		RetrieveURL:                   dataset.String(TagRetrieveURL),
+		Dataset:                       dataset,
+	}
+	return nil
 }
 
 // DicomWebSeries represents a series returned by QIDO-RS
 type DicomWebSeries struct {
+	// Study Instance UID (0020,000D)
+	StudyInstanceUID string
 	// Series Instance UID (0020,000E)
-	SeriesInstanceUID string `json:"0020000E,omitempty"`
+	SeriesInstanceUID string
 	// Modality (0008,0060)
-	Modality string `json:"00080060,omitempty"`
+	Modality string
 	// Series Number (0020,0011)
-	SeriesNumber string `json:"00200011,omitempty"`
+	SeriesNumber int
 	// Series Description (0008,103E)
-	SeriesDescription string `json:"0008103E,omitempty"`
+	SeriesDescription string
 	// Number of Series Related Instances (0020,1209)
-	NumberOfSeriesRelatedInstances string `json:"00201209,omitempty"`
+	NumberOfSeriesRelatedInstances int
 	// Performed Procedure Step Start Date (0040,0244)
-	PerformedProcedureStepStartDate string `json:"00400244,omitempty"`
+	PerformedProcedureStepStartDate string
 	// Performed Procedure Step Start Time (0040,0245)
-	PerformedProcedureStepStartTime string `json:"00400245,omitempty"`
+	PerformedProcedureStepStartTime string
+	// Retrieve URL (0008,1190)
+	RetrieveURL string
+	// All the attributes of the result, including the ones requested with includefield
+	Dataset DicomJSONDataset
+}
+
+// MarshalJSON encodes the series in the DICOM JSON model
+// The typed fields take precedence, Dataset provides the other attributes.
+func (s DicomWebSeries) MarshalJSON() ([]byte, error) {
+	dataset := s.Dataset.clone()
+	dataset.setString(TagStudyInstanceUID, "UI", s.StudyInstanceUID)
+	dataset.setString(TagSeriesInstanceUID, "UI", s.SeriesInstanceUID)
+	dataset.setString(TagModality, "CS", s.Modality)
+	dataset.setInt(TagSeriesNumber, "IS", s.SeriesNumber)
+	dataset.setString(TagSeriesDescription, "LO", s.SeriesDescription)
+	dataset.setInt(TagNumberOfSeriesRelatedInstances, "IS", s.NumberOfSeriesRelatedInstances)
+	dataset.setString(TagPerformedProcedureStepStartDate, "DA", s.PerformedProcedureStepStartDate)
+	dataset.setString(TagPerformedProcedureStepStartTime, "TM", s.PerformedProcedureStepStartTime)
+	dataset.setString(TagRetrieveURL, "UR", s.RetrieveURL)
+	return json.Marshal(dataset)
+}
+
+// UnmarshalJSON decodes a series from the DICOM JSON model
+func (s *DicomWebSeries) UnmarshalJSON(data []byte) error {
+	var dataset DicomJSONDataset
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return err
+	}
+
+	*s = DicomWebSeries{
+		StudyInstanceUID:                dataset.String(TagStudyInstanceUID),
+		SeriesInstanceUID:               dataset.String(TagSeriesInstanceUID),
+		Modality:                        dataset.String(TagModality),
+		SeriesNumber:                    dataset.Int(TagSeriesNumber),
+		SeriesDescription:               dataset.String(TagSeriesDescription),
+		NumberOfSeriesRelatedInstances:  dataset.Int(TagNumberOfSeriesRelatedInstances),
+		PerformedProcedureStepStartDate: dataset.String(TagPerformedProcedureStepStartDate),
+		PerformedProcedureStepStartTime: dataset.String(TagPerformedProcedureStepStartTime),
+		RetrieveURL:                     dataset.String(TagRetrieveURL),
+		Dataset:                         dataset,
+	}
+	return nil
 }
 
 // DicomWebInstance represents an instance returned by QIDO-RS
 type DicomWebInstance struct {
+	// Study Instance UID (0020,000D)
+	StudyInstanceUID string
+	// Series Instance UID (0020,000E)
+	SeriesInstanceUID string
 	// SOP Class UID (0008,0016)
-	SOPClassUID string `json:"00080016,omitempty"`
+	SOPClassUID string
 	// SOP Instance UID (0008,0018)
-	SOPInstanceUID string `json:"00080018,omitempty"`
+	SOPInstanceUID string
 	// Instance Number (0020,0013)
-	InstanceNumber string `json:"00200013,omitempty"`
+	InstanceNumber int
 	// Rows (0028,0010)
-	Rows string `json:"00280010,omitempty"`
+	Rows int
 	// Columns (0028,0011)
-	Columns string `json:"00280011,omitempty"`
+	Columns int
 	// Bits Allocated (0028,0100)
-	BitsAllocated string `json:"00280100,omitempty"`
+	BitsAllocated int
 	// Number of Frames (0028,0008)
-	NumberOfFrames string `json:"00280008,omitempty"`
+	NumberOfFrames int
+	// Retrieve URL (0008,1190)
+	RetrieveURL string
+	// All the attributes of the result, including the ones requested with includefield
+	Dataset DicomJSONDataset
+}
+
+// MarshalJSON encodes the instance in the DICOM JSON model
+// The typed fields take precedence, Dataset provides the other attributes.
+func (i DicomWebInstance) MarshalJSON() ([]byte, error) {
+	dataset := i.Dataset.clone()
+	dataset.setString(TagStudyInstanceUID, "UI", i.StudyInstanceUID)
+	dataset.setString(TagSeriesInstanceUID, "UI", i.SeriesInstanceUID)
+	dataset.setString(TagSOPClassUID, "UI", i.SOPClassUID)
+	dataset.setString(TagSOPInstanceUID, "UI", i.SOPInstanceUID)
+	dataset.setInt(TagInstanceNumber, "IS", i.InstanceNumber)
+	dataset.setInt(TagRows, "US", i.Rows)
+	dataset.setInt(TagColumns, "US", i.Columns)
+	dataset.setInt(TagBitsAllocated, "US", i.BitsAllocated)
+	dataset.setInt(TagNumberOfFrames, "IS", i.NumberOfFrames)
+	dataset.setString(TagRetrieveURL, "UR", i.RetrieveURL)
+	return json.Marshal(dataset)
+}
+
+// UnmarshalJSON decodes an instance from the DICOM JSON model
+func (i *DicomWebInstance) UnmarshalJSON(data []byte) error {
+	var dataset DicomJSONDataset
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return err
+	}
+
+	*i = DicomWebInstance{
+		StudyInstanceUID:  dataset.String(TagStudyInstanceUID),
+		SeriesInstanceUID: dataset.String(TagSeriesInstanceUID),
+		SOPClassUID:       dataset.String(TagSOPClassUID),
+		SOPInstanceUID:    dataset.String(TagSOPInstanceUID),
+		InstanceNumber:    dataset.Int(TagInstanceNumber),
+		Rows:              dataset.Int(TagRows),
+		Columns:           dataset.Int(TagColumns),
+		BitsAllocated:     dataset.Int(TagBitsAllocated),
+		NumberOfFrames:    dataset.Int(TagNumberOfFrames),
+		RetrieveURL:       dataset.String(TagRetrieveURL),
+		Dataset:           dataset,
+	}
+	return nil
 }
 
 // QidoQueryParams represents common query parameters for QIDO-RS requests
@@ -68,10 +225,29 @@ type QidoQueryParams struct {
 	Limit int
 	// Skip the first N results
 	Offset int
-	// Include all DICOM fields in the response
-	Includefield string
+	// Additional attributes to include in the results, as keywords or tags (e.g., "PatientAge", "00101010"), or "all"
+	Includefield []string
 	// Filter by fuzzy matching
 	FuzzyMatching bool
+	// Arbitrary attribute matching, keyed by keyword or tag (e.g., "PatientSex" or "00100040")
+	// Values support wildcards ("Doe*"), ranges ("20240101-20241231", see QidoDateRange) and UID lists ("1.2.3,1.2.4").
+	// Sequence attributes are matched with a dotted path (e.g., "RequestAttributesSequence.ScheduledProcedureStepID").
+	// An empty value requests the attribute in the results without filtering on it.
+	// Entries take precedence over the dedicated fields of the level-specific parameters.
+	Match map[string]string
+}
+
+// QidoDateRange builds a date range matching value (YYYYMMDD-YYYYMMDD) for QIDO-RS
+// A zero from or to leaves the range open on that side.
+func QidoDateRange(from, to time.Time) string {
+	var start, end string
+	if !from.IsZero() {
+		start = from.Format("20060102")
+	}
+	if !to.IsZero() {
+		end = to.Format("20060102")
+	}
+	return start + "-" + end
 }
 
 // QidoStudyQueryParams represents query parameters for study-level QIDO-RS
@@ -162,3 +338,34 @@ type WadoRsRenderedParams struct {
 	// Viewport size (format: rows,columns)
 	Viewport string
 }
+
+// StowRsInstanceResult represents the outcome of storing a single instance through STOW-RS
+type StowRsInstanceResult struct {
+	// Referenced SOP Class UID (0008,1150)
+	SOPClassUID string
+	// Referenced SOP Instance UID (0008,1155)
+	SOPInstanceUID string
+	// Retrieve URL of the stored instance (0008,1190), empty on failure
+	RetrieveURL string
+	// Failure Reason (0008,1197), 0 on success
+	FailureReason int
+	// Warning Reason (0008,1196), 0 if there is no warning
+	WarningReason int
+}
+
+// StowRsResponse represents the response of a STOW-RS request
+type StowRsResponse struct {
+	// Retrieve URL of the study (0008,1190)
+	RetrieveURL string
+	// Instances that were stored, from the Referenced SOP Sequence (0008,1199)
+	Stored []StowRsInstanceResult
+	// Instances that could not be stored, from the Failed SOP Sequence (0008,1198)
+	Failed []StowRsInstanceResult
+}
+
+// WadoRsRetrieveParams represents parameters for WADO-RS retrieval of instances and frames
+type WadoRsRetrieveParams struct {
+	// Transfer syntax UID requested in the Accept header (e.g., "1.2.840.10008.1.2.1")
+	// Use "*" to accept the stored transfer syntax, leave empty for the server default
+	TransferSyntax string
+}
@@ -46,3 +46,75 @@ type DicomWebServerCreateRequest struct {
 	// Set to false if target is Orthanc DICOMweb plugin <= 1.0
 	HasWadoRsUniversalTransferSyntax *bool `json:"HasWadoRsUniversalTransferSyntax,omitempty"`
 }
+
+// DicomWebServerResource identifies a study, series or instance on a remote DICOMweb server
+type DicomWebServerResource struct {
+	// Study Instance UID
+	Study string `json:"Study"`
+
+	// Series Instance UID (optional, to target a single series)
+	Series string `json:"Series,omitempty"`
+
+	// SOP Instance UID (optional, to target a single instance of the series)
+	Instance string `json:"Instance,omitempty"`
+}
+
+// DicomWebServerStowRequest represents a request to send local resources to a remote DICOMweb server
+type DicomWebServerStowRequest struct {
+	// Orthanc identifiers of the patients, studies, series or instances to send
+	Resources []string `json:"Resources"`
+
+	// Additional HTTP headers sent to the remote server
+	HttpHeaders map[string]string `json:"HttpHeaders,omitempty"`
+
+	// Whether to wait for the transfer, false to run it as a job
+	Synchronous *bool `json:"Synchronous,omitempty"`
+
+	// Defines the priority of the job (only used in asynchronous mode)
+	Priority int `json:"Priority,omitempty"`
+}
+
+// DicomWebServerGetRequest represents a request forwarded to a remote DICOMweb server
+type DicomWebServerGetRequest struct {
+	// URI relative to the root of the remote server (e.g., "/studies")
+	Uri string `json:"Uri"`
+
+	// Query arguments (e.g., {"PatientID": "123", "limit": "10"})
+	Arguments map[string]string `json:"Arguments,omitempty"`
+
+	// Additional HTTP headers sent to the remote server
+	HttpHeaders map[string]string `json:"HttpHeaders,omitempty"`
+}
+
+// DicomWebServerRetrieveRequest represents a request to pull resources from a remote DICOMweb server into Orthanc
+type DicomWebServerRetrieveRequest struct {
+	// Studies, series or instances to retrieve
+	Resources []DicomWebServerResource `json:"Resources"`
+
+	// Additional query arguments of the WADO-RS requests
+	Arguments map[string]string `json:"Arguments,omitempty"`
+
+	// Additional HTTP headers sent to the remote server
+	HttpHeaders map[string]string `json:"HttpHeaders,omitempty"`
+
+	// Whether to wait for the transfer, false to run it as a job
+	Synchronous *bool `json:"Synchronous,omitempty"`
+
+	// Defines the priority of the job (only used in asynchronous mode)
+	Priority int `json:"Priority,omitempty"`
+}
+
+// DicomWebServerRetrieveResult represents the result of a synchronous retrieval from a remote DICOMweb server
+type DicomWebServerRetrieveResult struct {
+	// Orthanc identifiers of the retrieved instances
+	Instances []string `json:"Instances"`
+}
+
+// DicomWebServerDeleteRequest represents a request to delete resources on a remote DICOMweb server
+type DicomWebServerDeleteRequest struct {
+	// Studies, series or instances to delete
+	Resources []DicomWebServerResource `json:"Resources"`
+
+	// Additional HTTP headers sent to the remote server
+	HttpHeaders map[string]string `json:"HttpHeaders,omitempty"`
+}
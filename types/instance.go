@@ -116,7 +116,10 @@ type InstanceDicomTags struct {
 
 // InstanceQueryParams represents query parameters for GET /instances
 type InstancesQueryParams struct {
-	// TODO: In future, support expand, short, full and requested-tags
+	// TODO: In future, support short, full and requested-tags
+	// Expand the response to include full details
+	Expand bool
+
 	// Return results starting from this index
 	Since int
 
@@ -171,7 +174,7 @@ type InstancesAnonymizeRequest struct {
 	// If true, ignore errors during the individual steps of the job.
 	Permissive *bool `json:"Permissive,omitempty"`
 
-	// Defines the priority of the job (Only work on async mode, which isn't implemented)
+	// Defines the priority of the job (only used in asynchronous mode)
 	Priority int `json:"Priority,omitempty"`
 
 	// Transcode the DICOM instance to the provided transfersyntax (https://orthanc.uclouvain.be/book/faq/transcoding.html)
@@ -0,0 +1,72 @@
+package types
+
+// JobState represents the state of an Orthanc job
+type JobState string
+
+const (
+	JobStatePending JobState = "Pending"
+	JobStateRunning JobState = "Running"
+	JobStateSuccess JobState = "Success"
+	JobStateFailure JobState = "Failure"
+	JobStatePaused  JobState = "Paused"
+	JobStateRetry   JobState = "Retry"
+)
+
+// Job represents detailed information about an Orthanc job
+type Job struct {
+	// Unique identifier of the job
+	ID string `json:"ID"`
+
+	// Type of the job (e.g., "ResourceModification", "DicomModalityStore", "Archive")
+	Type string `json:"Type"`
+
+	// Current state of the job
+	State JobState `json:"State"`
+
+	// Progress of the job, from 0 to 100
+	Progress int `json:"Progress"`
+
+	// Priority of the job (higher = more priority)
+	Priority int `json:"Priority"`
+
+	// Creation timestamp
+	CreationTime string `json:"CreationTime"`
+
+	// Completion timestamp (only set once the job is finished)
+	CompletionTime string `json:"CompletionTime,omitempty"`
+
+	// Estimated time of arrival (only set while the job is running)
+	EstimatedTimeOfArrival string `json:"EstimatedTimeOfArrival,omitempty"`
+
+	// Effective running time in seconds
+	EffectiveRuntime float64 `json:"EffectiveRuntime"`
+
+	// Last update timestamp
+	Timestamp string `json:"Timestamp"`
+
+	// Orthanc error code (0 on success)
+	ErrorCode int `json:"ErrorCode"`
+
+	// Human-readable description of the error
+	ErrorDescription string `json:"ErrorDescription"`
+
+	// Additional details about the error (Orthanc 1.12.0+)
+	ErrorDetails string `json:"ErrorDetails,omitempty"`
+
+	// Job-specific content, its structure depends on the job type
+	Content map[string]interface{} `json:"Content,omitempty"`
+}
+
+// IsFinished reports whether the job reached a final state
+func (j *Job) IsFinished() bool {
+	return j.State == JobStateSuccess || j.State == JobStateFailure
+}
+
+// AsyncJobResponse represents the response of an operation submitted in asynchronous mode
+type AsyncJobResponse struct {
+	// Identifier of the created job
+	ID string `json:"ID"`
+
+	// Path to access the job
+	Path string `json:"Path"`
+}
@@ -0,0 +1,52 @@
+package types
+
+// Metadata represents the value of a metadata attached to a resource
+type Metadata struct {
+	// Name of the metadata
+	Name string `json:"Name"`
+
+	// Value of the metadata
+	Value string `json:"Value"`
+
+	// Revision of the metadata, to be passed back when updating or deleting it
+	// Empty if the server does not report revisions
+	Revision string `json:"Revision,omitempty"`
+}
+
+// AttachmentInfo represents information about a file attached to a resource
+type AttachmentInfo struct {
+	// Numerical identifier of the attachment type (1 = DICOM, user-defined types start at 1024)
+	ContentType int `json:"ContentType"`
+
+	// Unique identifier of the file in the storage area
+	Uuid string `json:"Uuid"`
+
+	// Size of the file once uncompressed, in bytes
+	UncompressedSize int64 `json:"UncompressedSize"`
+
+	// MD5 hash of the uncompressed file
+	UncompressedMD5 string `json:"UncompressedMD5"`
+
+	// Size of the file in the storage area, in bytes
+	CompressedSize int64 `json:"CompressedSize"`
+
+	// MD5 hash of the file in the storage area
+	CompressedMD5 string `json:"CompressedMD5"`
+
+	// Revision of the attachment, to be passed back when updating or deleting it
+	// Empty if the server does not report revisions
+	Revision string `json:"-"`
+}
+
+// Attachment represents the content of a file attached to a resource
+type Attachment struct {
+	// Name of the attachment
+	Name string
+
+	// Uncompressed content of the attachment
+	Data []byte
+
+	// Revision of the attachment, to be passed back when updating or deleting it
+	// Empty if the server does not report revisions
+	Revision string
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Modality represents a DICOM modality configuration
 type Modality struct {
 	// Application Entity Title (AET) of the remote modality
@@ -66,6 +68,36 @@ type ModalityCreateRequest struct {
 	Timeout int `json:"Timeout,omitempty"`
 }
 
+// ModalityEchoRequest represents the options of a C-ECHO request
+type ModalityEchoRequest struct {
+	// Timeout of the DICOM association in seconds (default: configured in Orthanc)
+	Timeout int `json:"Timeout,omitempty"`
+
+	// Also check that the modality answers an empty C-FIND query
+	CheckFind *bool `json:"CheckFind,omitempty"`
+}
+
+// DicomEchoRequest represents a C-ECHO request to a modality that is not registered in Orthanc
+type DicomEchoRequest struct {
+	// Application Entity Title (AET) of the remote modality
+	AET string `json:"AET"`
+
+	// Host/IP address of the remote modality
+	Host string `json:"Host"`
+
+	// Port number of the remote modality
+	Port int `json:"Port"`
+
+	// Manufacturer name (optional)
+	Manufacturer string `json:"Manufacturer,omitempty"`
+
+	// Timeout of the DICOM association in seconds (default: configured in Orthanc)
+	Timeout int `json:"Timeout,omitempty"`
+
+	// Also check that the modality answers an empty C-FIND query
+	CheckFind *bool `json:"CheckFind,omitempty"`
+}
+
 // ModalityEchoResult represents the result of a C-ECHO operation
 type ModalityEchoResult struct {
 	// Whether the echo was successful
@@ -74,8 +106,8 @@ type ModalityEchoResult struct {
 	// Error message if unsuccessful
 	ErrorMessage string `json:"ErrorMessage,omitempty"`
 
-	// Response time in milliseconds
-	ResponseTime int `json:"ResponseTime,omitempty"`
+	// Round-trip latency of the echo, as measured by the client
+	Latency time.Duration `json:"Latency,omitempty"`
 }
 
 // ModalityStoreRequest represents a request to store resources to a modality
@@ -103,7 +135,8 @@ type ModalityStoreRequest struct {
 
 	Permissive int `json:"Permissive,omitempty"`
 
-	StorageCommitment int `json:"StorageCommitment,omitempty"`
+	// Request a storage commitment from the modality after the transfer
+	StorageCommitment *bool `json:"StorageCommitment,omitempty"`
 }
 
 // ModalityStoreResult represents the result of a C-STORE operation
@@ -125,6 +158,9 @@ type ModalityStoreResult struct {
 
 	// Number of failed instances
 	FailedInstancesCount int `json:"FailedInstancesCount,omitempty"`
+
+	// UID of the storage commitment transaction, when storage commitment was requested
+	StorageCommitmentTransactionUID string `json:"StorageCommitmentTransactionUID,omitempty"`
 }
 
 // ModalityFindRequest represents a C-FIND query request
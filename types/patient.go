@@ -107,7 +107,7 @@ type PatientAnonymizeRequest struct {
 	// If true, ignore errors during the individual steps of the job.
 	Permissive *bool `json:"Permissive,omitempty"`
 
-	// Defines the priority of the job (Only work on async mode, which isn't implemented)
+	// Defines the priority of the job (only used in asynchronous mode)
 	Priority int `json:"Priority,omitempty"`
 
 	// Transcode the DICOM instance to the provided transfersyntax (https://orthanc.uclouvain.be/book/faq/transcoding.html)
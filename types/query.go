@@ -0,0 +1,40 @@
+package types
+
+// QueryAnswersOptions configures the format of the DICOM tags of query answers
+type QueryAnswersOptions struct {
+	// Report the tags by name with their value only (e.g., "PatientName": "DOE^JOHN")
+	Simplify bool
+
+	// Report the tags by hexadecimal tag with their value only (e.g., "0010,0010": "DOE^JOHN")
+	Short bool
+}
+
+// QueryRetrieveRequest represents a request to retrieve the answers of a query
+type QueryRetrieveRequest struct {
+	// AET of the modality receiving the instances (default: Orthanc itself)
+	TargetAet string `json:"TargetAet,omitempty"`
+
+	// DICOM operation used to retrieve the instances, "C-MOVE" or "C-GET" (default: configured in Orthanc)
+	RetrieveMethod string `json:"RetrieveMethod,omitempty"`
+
+	// Timeout of the DICOM association in seconds
+	Timeout int `json:"Timeout,omitempty"`
+
+	// If true, ignore errors during the individual steps of the job
+	Permissive *bool `json:"Permissive,omitempty"`
+
+	// If true, the REST API will return a Job ID and the job will be put in a queue
+	Asynchronous *bool `json:"Asynchronous,omitempty"`
+
+	// Defines the priority of the job (only used in asynchronous mode)
+	Priority int `json:"Priority,omitempty"`
+}
+
+// QueryChildrenRequest represents a request to query the children of a query answer
+type QueryChildrenRequest struct {
+	// Additional filters on the DICOM tags of the children
+	Query map[string]string `json:"Query,omitempty"`
+
+	// Timeout of the DICOM association in seconds
+	Timeout int `json:"Timeout,omitempty"`
+}
@@ -0,0 +1,50 @@
+package types
+
+// StorageCommitmentStatus represents the status of a storage commitment transaction
+type StorageCommitmentStatus string
+
+const (
+	// StorageCommitmentStatusPending means the N-EVENT-REPORT has not been received yet
+	StorageCommitmentStatusPending StorageCommitmentStatus = "Pending"
+
+	// StorageCommitmentStatusSuccess means the remote modality committed all the instances
+	StorageCommitmentStatusSuccess StorageCommitmentStatus = "Success"
+
+	// StorageCommitmentStatusFailure means the remote modality failed to commit some instances
+	StorageCommitmentStatusFailure StorageCommitmentStatus = "Failure"
+)
+
+// StorageCommitmentInstance represents an instance reported in a storage commitment report
+type StorageCommitmentInstance struct {
+	// SOP Class UID of the instance
+	SOPClassUID string `json:"SOPClassUID"`
+
+	// SOP Instance UID of the instance
+	SOPInstanceUID string `json:"SOPInstanceUID"`
+
+	// DICOM failure reason (e.g., 0x0110 for a processing failure), only set for failures
+	FailureReason int `json:"FailureReason,omitempty"`
+}
+
+// StorageCommitmentReport represents the outcome of a storage commitment transaction
+type StorageCommitmentReport struct {
+	// UID of the transaction, filled in by the client as Orthanc does not report it
+	TransactionUID string `json:"-"`
+
+	// Status of the transaction
+	Status StorageCommitmentStatus `json:"Status"`
+
+	// AET of the modality that sent the report
+	RemoteAET string `json:"RemoteAET"`
+
+	// Instances committed by the remote modality
+	Success []StorageCommitmentInstance `json:"Success"`
+
+	// Instances the remote modality failed to commit
+	Failures []StorageCommitmentInstance `json:"Failures"`
+}
+
+// IsPending returns true while the report has not been received
+func (r *StorageCommitmentReport) IsPending() bool {
+	return r.Status == StorageCommitmentStatusPending
+}
@@ -103,7 +103,26 @@ type ModifyRequest struct {
 	KeepPrivateTags *bool `json:"KeepPrivateTags,omitempty"`
 
 	// Force operation even if it would create an invalid DICOM file
+	// Required when replacing identifiers such as PatientID or StudyInstanceUID
 	Force *bool `json:"Force,omitempty"`
+
+	// Private creator to use for private tags in Replace
+	PrivateCreator string `json:"PrivateCreator,omitempty"`
+
+	// Transcode the DICOM instances to the provided transfer syntax
+	Transcode string `json:"Transcode,omitempty"`
+
+	// By default orthanc keeps the source resource, set this to false to delete it after the modification
+	KeepSource *bool `json:"KeepSource,omitempty"`
+
+	// If true, the REST API will return a Job ID and the job will be put in a queue
+	Asynchronous *bool `json:"Asynchronous,omitempty"`
+
+	// If true, ignore errors during the individual steps of the job.
+	Permissive *bool `json:"Permissive,omitempty"`
+
+	// Defines the priority of the job (only used in asynchronous mode)
+	Priority int `json:"Priority,omitempty"`
 }
 
 // AnonymizeRequest represents a request to anonymize a study
@@ -123,7 +142,7 @@ type StudyAnonymizeRequest struct {
 	// If true, ignore errors during the individual steps of the job.
 	Permissive *bool `json:"Permissive,omitempty"`
 
-	// Defines the priority of the job (Only work on async mode, which isn't implemented)
+	// Defines the priority of the job (only used in asynchronous mode)
 	Priority int `json:"Priority,omitempty"`
 
 	// Transcode the DICOM instance to the provided transfersyntax (https://orthanc.uclouvain.be/book/faq/transcoding.html)
@@ -157,24 +176,44 @@ type StudyAnonymizeResponse struct {
 	Type string `json:"Type,omitempty"`
 }
 
-// ModifyResponse represents the response from modify/anonymize operations
+// UploadStatus represents the outcome of the upload of a DICOM file
+type UploadStatus string
+
+const (
+	// UploadStatusSuccess means the instance was stored
+	UploadStatusSuccess UploadStatus = "Success"
+
+	// UploadStatusAlreadyStored means the instance was already present in Orthanc
+	UploadStatusAlreadyStored UploadStatus = "AlreadyStored"
+
+	// UploadStatusFilteredOut means the instance was rejected by a filter (e.g. a Lua or plugin callback)
+	UploadStatusFilteredOut UploadStatus = "FilteredOut"
+
+	// UploadStatusFailure means the upload failed
+	UploadStatusFailure UploadStatus = "Failure"
+
+	// UploadStatusSkipped means the file is not a DICOM file and was not uploaded
+	UploadStatusSkipped UploadStatus = "Skipped"
+)
+
+// UploadDicomFileResponse represents the response from POST /instances
 type UploadDicomFileResponse struct {
-	// ID of the newly created resource
+	// ID of the newly created instance
 	ID string `json:"ID"`
 
-	// Path to the newly created resource
+	// Path to the newly created instance
 	Path string `json:"Path"`
 
-	// Type of the resource (Study, Series, etc.)
-	Status string `json:"Status"`
+	// Outcome of the upload ("Success", "AlreadyStored" or "FilteredOut")
+	Status UploadStatus `json:"Status"`
 
-	// Type of the resource (Study, Series, etc.)
+	// ID of the parent study
 	ParentStudy string `json:"ParentStudy"`
 
-	// Type of the resource (Study, Series, etc.)
-	ParentSeries string `json:"ParientSeries"`
+	// ID of the parent series
+	ParentSeries string `json:"ParentSeries"`
 
-	// Type of the resource (Study, Series, etc.)
+	// ID of the parent patient
 	ParentPatient string `json:"ParentPatient"`
 }
 
@@ -186,6 +225,9 @@ type ModifyResponse struct {
 	// Path to the newly created resource
 	Path string `json:"Path"`
 
+	// Orthanc Patient ID of the newly created resource
+	PatientID string `json:"PatientID,omitempty"`
+
 	// Type of the resource (Study, Series, etc.)
 	Type string `json:"Type"`
 }
@@ -10,6 +10,18 @@ const (
 	ResourceLevelInstance ResourceLevel = "Instance"
 )
 
+// LabelsConstraint represents how the labels of a ToolsFindRequest are combined
+const (
+	// LabelsConstraintAll matches resources having all the labels
+	LabelsConstraintAll = "All"
+
+	// LabelsConstraintAny matches resources having at least one of the labels
+	LabelsConstraintAny = "Any"
+
+	// LabelsConstraintNone matches resources having none of the labels
+	LabelsConstraintNone = "None"
+)
+
 // LogLevel represents the logging verbosity level in Orthanc
 type LogLevel string
 
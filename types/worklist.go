@@ -0,0 +1,113 @@
+package types
+
+import "encoding/json"
+
+// WorklistFindRequest represents a modality worklist C-FIND request
+// Empty fields are still sent as return keys, so that the answers carry the matching attributes.
+// Text fields support wildcards (e.g., "DOE*") and dates support ranges (e.g., "20240101-20240131").
+type WorklistFindRequest struct {
+	// Filter by Patient's Name (0010,0010)
+	PatientName string
+
+	// Filter by Patient ID (0010,0020)
+	PatientID string
+
+	// Filter by Accession Number (0008,0050)
+	AccessionNumber string
+
+	// Filter by Modality of the scheduled procedure step (0040,0100 > 0008,0060)
+	Modality string
+
+	// Filter by Scheduled Station AE Title of the scheduled procedure step (0040,0100 > 0040,0001)
+	ScheduledStationAETitle string
+
+	// Filter by Scheduled Procedure Step Start Date (0040,0100 > 0040,0002), see QidoDateRange
+	ScheduledProcedureStepStartDate string
+
+	// Additional top-level filters or return keys, by keyword (e.g., "RequestedProcedureID")
+	Query map[string]string
+
+	// Additional filters or return keys within the Scheduled Procedure Step Sequence, by keyword
+	ScheduledProcedureStepQuery map[string]string
+
+	// Timeout of the DICOM association in seconds
+	Timeout int
+}
+
+// ScheduledProcedureStep represents an item of the Scheduled Procedure Step Sequence (0040,0100)
+type ScheduledProcedureStep struct {
+	// Modality (0008,0060)
+	Modality string `json:"Modality,omitempty"`
+
+	// Scheduled Station AE Title (0040,0001)
+	ScheduledStationAETitle string `json:"ScheduledStationAETitle,omitempty"`
+
+	// Scheduled Station Name (0040,0010)
+	ScheduledStationName string `json:"ScheduledStationName,omitempty"`
+
+	// Scheduled Procedure Step Start Date (0040,0002)
+	ScheduledProcedureStepStartDate string `json:"ScheduledProcedureStepStartDate,omitempty"`
+
+	// Scheduled Procedure Step Start Time (0040,0003)
+	ScheduledProcedureStepStartTime string `json:"ScheduledProcedureStepStartTime,omitempty"`
+
+	// Scheduled Performing Physician's Name (0040,0006)
+	ScheduledPerformingPhysicianName string `json:"ScheduledPerformingPhysicianName,omitempty"`
+
+	// Scheduled Procedure Step Description (0040,0007)
+	ScheduledProcedureStepDescription string `json:"ScheduledProcedureStepDescription,omitempty"`
+
+	// Scheduled Procedure Step ID (0040,0009)
+	ScheduledProcedureStepID string `json:"ScheduledProcedureStepID,omitempty"`
+}
+
+// WorklistItem represents an answer to a modality worklist C-FIND request
+type WorklistItem struct {
+	// Patient's Name (0010,0010)
+	PatientName string `json:"PatientName,omitempty"`
+
+	// Patient ID (0010,0020)
+	PatientID string `json:"PatientID,omitempty"`
+
+	// Patient's Birth Date (0010,0030)
+	PatientBirthDate string `json:"PatientBirthDate,omitempty"`
+
+	// Patient's Sex (0010,0040)
+	PatientSex string `json:"PatientSex,omitempty"`
+
+	// Accession Number (0008,0050)
+	AccessionNumber string `json:"AccessionNumber,omitempty"`
+
+	// Study Instance UID (0020,000D)
+	StudyInstanceUID string `json:"StudyInstanceUID,omitempty"`
+
+	// Referring Physician's Name (0008,0090)
+	ReferringPhysicianName string `json:"ReferringPhysicianName,omitempty"`
+
+	// Requested Procedure ID (0040,1001)
+	RequestedProcedureID string `json:"RequestedProcedureID,omitempty"`
+
+	// Requested Procedure Description (0032,1060)
+	RequestedProcedureDescription string `json:"RequestedProcedureDescription,omitempty"`
+
+	// Scheduled Procedure Step Sequence (0040,0100)
+	ScheduledProcedureSteps []ScheduledProcedureStep `json:"ScheduledProcedureStepSequence,omitempty"`
+
+	// All the tags of the answer, including the ones requested through Query
+	Tags map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes a worklist item, keeping all of its tags
+func (w *WorklistItem) UnmarshalJSON(data []byte) error {
+	type worklistItem WorklistItem
+	var item worklistItem
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &item.Tags); err != nil {
+		return err
+	}
+
+	*w = WorklistItem(item)
+	return nil
+}
@@ -0,0 +1,298 @@
+package gorthanc
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+const defaultUploadWorkers = 4
+
+// dicomPreambleLength is the size of the preamble preceding the "DICM" prefix of a DICOM file
+const dicomPreambleLength = 128
+
+// BulkUploadOptions configures a BulkUploader
+type BulkUploadOptions struct {
+	// Number of files uploaded concurrently (default: 4)
+	Workers int
+
+	// Called once per file with its outcome, from a single goroutine (optional)
+	OnFile func(result UploadFileResult)
+}
+
+// UploadFileResult represents the outcome of the upload of a single file
+type UploadFileResult struct {
+	// Path of the file, relative to the uploaded directory or ZIP archive
+	Name string
+
+	// Outcome of the upload
+	Status types.UploadStatus
+
+	// Response from Orthanc, nil if the file was skipped or failed
+	Response *types.UploadDicomFileResponse
+
+	// Error that made the upload fail, nil otherwise
+	Err error
+}
+
+// BulkUploadSummary summarizes a bulk upload
+type BulkUploadSummary struct {
+	// Number of files stored
+	Succeeded int
+
+	// Number of files already present in Orthanc
+	AlreadyStored int
+
+	// Number of files rejected by a filter on the server
+	FilteredOut int
+
+	// Number of files that could not be uploaded
+	Failed int
+
+	// Number of non-DICOM files that were skipped
+	Skipped int
+
+	// Orthanc IDs of the patients, studies and series the uploaded files belong to
+	Patients []string
+	Studies  []string
+	Series   []string
+
+	// Results of the files that could not be uploaded
+	Failures []UploadFileResult
+}
+
+// BulkUploader uploads many DICOM files concurrently
+type BulkUploader struct {
+	client *Client
+	opts   BulkUploadOptions
+}
+
+// uploadTask is a file waiting to be uploaded
+type uploadTask struct {
+	name string
+	open func() (io.ReadSeekCloser, error)
+}
+
+// NewBulkUploader creates an uploader of directories and ZIP archives
+func (c *Client) NewBulkUploader(opts *BulkUploadOptions) *BulkUploader {
+	u := &BulkUploader{client: c}
+	if opts != nil {
+		u.opts = *opts
+	}
+
+	if u.opts.Workers <= 0 {
+		u.opts.Workers = defaultUploadWorkers
+	}
+
+	return u
+}
+
+// UploadDirectory uploads all the DICOM files found in a directory and its subdirectories
+// Files that do not start with the DICOM preamble are skipped. The upload stops early
+// when ctx is done, returning the summary of the files processed so far.
+func (u *BulkUploader) UploadDirectory(ctx context.Context, dir string) (*BulkUploadSummary, error) {
+	return u.run(ctx, func(tasks chan<- uploadTask) error {
+		return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+
+			name, err := filepath.Rel(dir, path)
+			if err != nil {
+				name = path
+			}
+
+			task := uploadTask{
+				name: name,
+				open: func() (io.ReadSeekCloser, error) {
+					return os.Open(path)
+				},
+			}
+
+			select {
+			case tasks <- task:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	})
+}
+
+// UploadZip uploads all the DICOM files contained in a ZIP archive
+// Each file is extracted in memory before being uploaded. See UploadDirectory.
+func (u *BulkUploader) UploadZip(ctx context.Context, zipPath string) (*BulkUploadSummary, error) {
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer archive.Close()
+
+	return u.run(ctx, func(tasks chan<- uploadTask) error {
+		for _, file := range archive.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+
+			task := uploadTask{
+				name: file.Name,
+				open: func() (io.ReadSeekCloser, error) {
+					rc, err := file.Open()
+					if err != nil {
+						return nil, err
+					}
+					defer rc.Close()
+
+					data, err := io.ReadAll(rc)
+					if err != nil {
+						return nil, err
+					}
+					return nopReadSeekCloser{bytes.NewReader(data)}, nil
+				},
+			}
+
+			select {
+			case tasks <- task:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// run feeds the tasks produced by produce to the workers and collects their results
+func (u *BulkUploader) run(ctx context.Context, produce func(tasks chan<- uploadTask) error) (*BulkUploadSummary, error) {
+	tasks := make(chan uploadTask)
+	results := make(chan UploadFileResult)
+
+	var workers sync.WaitGroup
+	for range u.opts.Workers {
+		workers.Go(func() {
+			for task := range tasks {
+				results <- u.upload(ctx, task)
+			}
+		})
+	}
+
+	var produceErr error
+	go func() {
+		produceErr = produce(tasks)
+		close(tasks)
+		workers.Wait()
+		close(results)
+	}()
+
+	summary := &BulkUploadSummary{}
+	patients := map[string]struct{}{}
+	studies := map[string]struct{}{}
+	series := map[string]struct{}{}
+
+	for result := range results {
+		switch result.Status {
+		case types.UploadStatusSuccess:
+			summary.Succeeded++
+		case types.UploadStatusAlreadyStored:
+			summary.AlreadyStored++
+		case types.UploadStatusFilteredOut:
+			summary.FilteredOut++
+		case types.UploadStatusSkipped:
+			summary.Skipped++
+		default:
+			summary.Failed++
+			summary.Failures = append(summary.Failures, result)
+		}
+
+		if result.Response != nil {
+			patients[result.Response.ParentPatient] = struct{}{}
+			studies[result.Response.ParentStudy] = struct{}{}
+			series[result.Response.ParentSeries] = struct{}{}
+		}
+
+		if u.opts.OnFile != nil {
+			u.opts.OnFile(result)
+		}
+	}
+
+	summary.Patients = slices.Sorted(maps.Keys(patients))
+	summary.Studies = slices.Sorted(maps.Keys(studies))
+	summary.Series = slices.Sorted(maps.Keys(series))
+
+	return summary, produceErr
+}
+
+// upload uploads a single file, skipping it if it is not a DICOM file
+func (u *BulkUploader) upload(ctx context.Context, task uploadTask) UploadFileResult {
+	result := UploadFileResult{Name: task.name, Status: types.UploadStatusFailure}
+
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+
+	file, err := task.open()
+	if err != nil {
+		result.Err = fmt.Errorf("failed to open file: %w", err)
+		return result
+	}
+	defer file.Close()
+
+	isDicom, err := hasDicomPreamble(file)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to read file: %w", err)
+		return result
+	}
+	if !isDicom {
+		result.Status = types.UploadStatusSkipped
+		return result
+	}
+
+	response, err := u.client.UploadDicomFile(ctx, file)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Status = response.Status
+	result.Response = response
+	return result
+}
+
+// hasDicomPreamble checks for the "DICM" prefix following the 128-byte preamble,
+// then rewinds the reader to its start
+func hasDicomPreamble(r io.ReadSeeker) (bool, error) {
+	header := make([]byte, dicomPreambleLength+4)
+	_, err := io.ReadFull(r, header)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	return string(header[dicomPreambleLength:]) == "DICM", nil
+}
+
+// nopReadSeekCloser adds a no-op Close method to an io.ReadSeeker
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
@@ -0,0 +1,75 @@
+package gorthanc
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// endpointPath joins path segments, escaping each of them
+// so that identifiers and names containing spaces, slashes or other reserved characters stay a single segment.
+func endpointPath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = escapePathSegment(segment)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// escapePathSegment escapes a single path segment
+// Commas are valid in a path segment and are kept as is for frame lists (e.g. "1,2,3"),
+// while dot segments are escaped so that they are not resolved against the base URL.
+func escapePathSegment(segment string) string {
+	if segment == "." || segment == ".." {
+		return strings.ReplaceAll(segment, ".", "%2E")
+	}
+	return strings.ReplaceAll(url.PathEscape(segment), "%2C", ",")
+}
+
+// escapeQueryValue escapes a query parameter name or value
+// Spaces are sent as %20 rather than +, which not every server decodes.
+func escapeQueryValue(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// queryBuilder builds an escaped query string, keeping the parameters in the order they were added
+type queryBuilder struct {
+	params []string
+}
+
+// add adds a parameter, adding the same name again sends multiple values
+func (q *queryBuilder) add(name, value string) {
+	q.params = append(q.params, escapeQueryValue(name)+"="+escapeQueryValue(value))
+}
+
+// addInt adds an integer parameter
+func (q *queryBuilder) addInt(name string, value int) {
+	q.add(name, strconv.Itoa(value))
+}
+
+// addInt64 adds a 64-bit integer parameter
+func (q *queryBuilder) addInt64(name string, value int64) {
+	q.add(name, strconv.FormatInt(value, 10))
+}
+
+// addPage adds the since and limit parameters of a paginated list
+// Orthanc requires since and limit to be provided together, so nothing is added without a limit.
+func (q *queryBuilder) addPage(since, limit int) {
+	if limit > 0 {
+		q.addInt("since", since)
+		q.addInt("limit", limit)
+	}
+}
+
+// addFlag adds a parameter without a value (e.g. "expand")
+func (q *queryBuilder) addFlag(name string) {
+	q.params = append(q.params, escapeQueryValue(name))
+}
+
+// encode returns the query string including the leading "?", or an empty string without parameters
+func (q *queryBuilder) encode() string {
+	if len(q.params) == 0 {
+		return ""
+	}
+	return "?" + strings.Join(q.params, "&")
+}
@@ -0,0 +1,141 @@
+package gorthanc
+
+import (
+	"testing"
+
+	"github.com/proencaj/gorthanc/types"
+)
+
+func TestEndpointPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []string
+		want     string
+	}{
+		{"plain", []string{"studies", "abc-123"}, "studies/abc-123"},
+		{"slash", []string{"modalities", "a/b", "echo"}, "modalities/a%2Fb/echo"},
+		{"space", []string{"peers", "my peer"}, "peers/my%20peer"},
+		{"dot", []string{"instances", "."}, "instances/%2E"},
+		{"dot dot", []string{"instances", "..", "file"}, "instances/%2E%2E/file"},
+		{"dots inside", []string{"dicom-web", "studies", "1.2.840.113619"}, "dicom-web/studies/1.2.840.113619"},
+		{"frame list", []string{"instances", "abc", "frames", "1,2,3"}, "instances/abc/frames/1,2,3"},
+		{"query characters", []string{"labels", "a?b#c"}, "labels/a%3Fb%23c"},
+		{"percent", []string{"metadata", "100%"}, "metadata/100%25"},
+		{"empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := endpointPath(tt.segments...); got != tt.want {
+				t.Errorf("endpointPath(%q) = %q, want %q", tt.segments, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(q *queryBuilder)
+		want  string
+	}{
+		{"empty", func(q *queryBuilder) {}, ""},
+		{"flag", func(q *queryBuilder) { q.addFlag("expand") }, "?expand"},
+		{"space", func(q *queryBuilder) { q.add("PatientName", "John Doe") }, "?PatientName=John%20Doe"},
+		{"caret in person name", func(q *queryBuilder) { q.add("PatientName", "Doe^John") }, "?PatientName=Doe%5EJohn"},
+		{"reserved characters", func(q *queryBuilder) { q.add("q", "a&b=c+d/e") }, "?q=a%26b%3Dc%2Bd%2Fe"},
+		{"integers", func(q *queryBuilder) {
+			q.addInt("since", 10)
+			q.addInt64("last", 1<<40)
+		}, "?since=10&last=1099511627776"},
+		{"page", func(q *queryBuilder) { q.addPage(20, 10) }, "?since=20&limit=10"},
+		{"page without limit", func(q *queryBuilder) { q.addPage(20, 0) }, ""},
+		{"repeated parameter", func(q *queryBuilder) {
+			q.add("includefield", "PatientAge")
+			q.add("includefield", "00101010")
+		}, "?includefield=PatientAge&includefield=00101010"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q queryBuilder
+			tt.build(&q)
+			if got := q.encode(); got != tt.want {
+				t.Errorf("encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildListPaths(t *testing.T) {
+	c := &Client{}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"studies nil", c.buildStudiesPath("studies", nil), "studies"},
+		{"studies since without limit", c.buildStudiesPath("studies", &types.StudiesQueryParams{Since: 5}), "studies"},
+		{"studies since and limit", c.buildStudiesPath("studies", &types.StudiesQueryParams{Since: 5, Limit: 10}), "studies?since=5&limit=10"},
+		{"studies limit only", c.buildStudiesPath("studies", &types.StudiesQueryParams{Limit: 10}), "studies?since=0&limit=10"},
+		{"studies flags", c.buildStudiesPath("studies", &types.StudiesQueryParams{Expand: true, Short: true, Full: true}), "studies?expand&short&full"},
+		{"series", c.buildSeriesPath("series", &types.SeriesQueryParams{Expand: true, Since: 2, Limit: 3}), "series?expand&since=2&limit=3"},
+		{"series since without limit", c.buildSeriesPath("series", &types.SeriesQueryParams{Since: 2}), "series"},
+		{"patients", c.buildPatientsPath("patients", &types.PatientQueryParams{Since: 1, Limit: 1}), "patients?since=1&limit=1"},
+		{"patients since without limit", c.buildPatientsPath("patients", &types.PatientQueryParams{Expand: true, Since: 1}), "patients?expand"},
+		{"instances", c.buildInstancePath("instances", &types.InstancesQueryParams{Expand: true, Limit: 50}), "instances?expand&since=0&limit=50"},
+		{"instance tags", c.buildInstanceTagsPath("instances/abc/tags", &types.GetInstanceTagsQueryParams{Short: true, Whole: true}), "instances/abc/tags?short=true&whole=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQidoQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		common  types.QidoQueryParams
+		filters map[string]string
+		want    string
+	}{
+		{"empty", types.QidoQueryParams{}, nil, ""},
+		{
+			"paging and repeated includefield",
+			types.QidoQueryParams{Limit: 10, Offset: 20, Includefield: []string{"PatientAge", "00101010"}, FuzzyMatching: true},
+			nil,
+			"?limit=10&offset=20&includefield=PatientAge&includefield=00101010&fuzzymatching=true",
+		},
+		{
+			"sorted filters without empty ones",
+			types.QidoQueryParams{},
+			map[string]string{"PatientName": "Doe^John", "AccessionNumber": "", "PatientID": "P 1"},
+			"?PatientID=P%201&PatientName=Doe%5EJohn",
+		},
+		{
+			"match overrides filters",
+			types.QidoQueryParams{Match: map[string]string{"PatientID": "P2", "00100040": ""}},
+			map[string]string{"PatientID": "P1", "StudyDate": "20240101-20241231"},
+			"?StudyDate=20240101-20241231&00100040=&PatientID=P2",
+		},
+		{
+			"uid list keeps its commas escaped",
+			types.QidoQueryParams{Match: map[string]string{"StudyInstanceUID": "1.2.3,1.2.4"}},
+			nil,
+			"?StudyInstanceUID=1.2.3%2C1.2.4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := qidoQuery(tt.common, tt.filters); got != tt.want {
+				t.Errorf("qidoQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}